@@ -0,0 +1,10 @@
+package logging
+
+// CallerSetter реализуют логгеры, которым можно явно задать место вызова записи.
+type CallerSetter interface {
+	// WithCaller возвращает производный логгер, записи которого указывают на pc
+	// (значение из runtime.Callers) вместо фактического места вызова. Используется,
+	// когда запись повторяется или пересылается из другого логгера, например при
+	// выводе сохраненных записей TestLogger или в адаптере slog.Handler.
+	WithCaller(pc uintptr) Logger
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/baggage"
+	"sync/atomic"
+)
+
+// BaggageLevelKey — ключ W3C baggage, из которого берется уровень запроса, например
+// "log-level=debug". Так флаг отладки передается между сервисами вместе с запросом.
+// Читается, только если включен SetBaggageLevelEnabled.
+const BaggageLevelKey = "log-level"
+
+var baggageLevelEnabled atomic.Bool
+
+// SetBaggageLevelEnabled включает чтение уровня запроса из baggage. По умолчанию выключено:
+// baggage приходит от клиента, и без доверия к нему любой запрос мог бы включить Trace.
+func SetBaggageLevelEnabled(enabled bool) {
+	baggageLevelEnabled.Store(enabled)
+}
+
+type ctxLevel struct{}
+
+// ContextWithLevel возвращает контекст, в котором логгеры запроса пишут на уровне level.
+func ContextWithLevel(ctx context.Context, level Level) context.Context {
+	return context.WithValue(ctx, ctxLevel{}, level)
+}
+
+// LevelFromContext возвращает уровень запроса: заданный через ContextWithLevel или,
+// если его нет и включен SetBaggageLevelEnabled, из элемента baggage BaggageLevelKey.
+// Нераспознанное значение в baggage игнорируется.
+func LevelFromContext(ctx context.Context) (Level, bool) {
+	if level, ok := ctx.Value(ctxLevel{}).(Level); ok {
+		return level, true
+	}
+	if !baggageLevelEnabled.Load() {
+		return 0, false
+	}
+	member := baggage.FromContext(ctx).Member(BaggageLevelKey)
+	if member.Key() == "" {
+		return 0, false
+	}
+	level, err := ParseLevel(member.Value())
+	if err != nil {
+		return 0, false
+	}
+	return level, true
+}
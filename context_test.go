@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"testing"
+)
+
+func TestLevelFromContext(t *testing.T) {
+	_, ok := LevelFromContext(context.Background())
+	assert.False(t, ok)
+
+	level, ok := LevelFromContext(ContextWithLevel(context.Background(), TraceLevel))
+	assert.True(t, ok)
+	assert.Equal(t, TraceLevel, level)
+}
+
+func TestLevelFromContext_Baggage(t *testing.T) {
+	withBaggage := func(value string) context.Context {
+		member, err := baggage.NewMember(BaggageLevelKey, value)
+		require.NoError(t, err)
+		bag, err := baggage.New(member)
+		require.NoError(t, err)
+		return baggage.ContextWithBaggage(context.Background(), bag)
+	}
+
+	_, ok := LevelFromContext(withBaggage("debug"))
+	assert.False(t, ok, "Baggage level should be ignored unless enabled")
+
+	SetBaggageLevelEnabled(true)
+	defer SetBaggageLevelEnabled(false)
+
+	level, ok := LevelFromContext(withBaggage("debug"))
+	assert.True(t, ok)
+	assert.Equal(t, DebugLevel, level)
+
+	_, ok = LevelFromContext(withBaggage("loud"))
+	assert.False(t, ok, "Unknown baggage value should be ignored")
+
+	level, _ = LevelFromContext(ContextWithLevel(withBaggage("debug"), TraceLevel))
+	assert.Equal(t, TraceLevel, level, "Explicit level should take precedence over baggage")
+}
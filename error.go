@@ -0,0 +1,52 @@
+package logging
+
+import "sync/atomic"
+
+// ErrorKey — поле с ошибкой ErrorCatch, FatalCatch и PanicCatch. Значение — объект
+// с полями ErrorMessageKey, ErrorTypeKey и, если ошибка оборачивает другие,
+// ErrorCausesKey: список причин в порядке обхода errors.Unwrap и errors.Join.
+// Рядом выводится стек в поле StacktraceKey (см. StacktraceOptions).
+const (
+	ErrorKey        = "error"
+	ErrorMessageKey = "message"
+	ErrorTypeKey    = "type"
+	ErrorCausesKey  = "causes"
+)
+
+// LogFielder реализуют ошибки, которые добавляют свои поля в записи ErrorCatch, FatalCatch
+// и PanicCatch, например идентификаторы заказа и арендатора. Поля берутся со всех ошибок
+// цепочки; при совпадении ключей побеждает внешняя ошибка, а ErrorKey и StacktraceKey
+// переопределить нельзя.
+type LogFielder interface {
+	LogFields() map[string]any
+}
+
+// StacktraceOptions настраивает поле StacktraceKey у ErrorCatch, FatalCatch и PanicCatch.
+type StacktraceOptions struct {
+	// Level — минимальный уровень записи, к которой добавляется стек. Чтобы не добавлять
+	// стек совсем, задайте уровень выше FatalLevel.
+	Level Level
+	// Depth — максимальное число кадров стека; 0 означает значение по умолчанию.
+	Depth int
+}
+
+// DefaultStacktraceOptions добавляет стек до 32 кадров к записям Error и выше.
+var DefaultStacktraceOptions = StacktraceOptions{Level: ErrorLevel, Depth: 32}
+
+var stacktraceOptions atomic.Pointer[StacktraceOptions]
+
+// SetStacktraceOptions меняет настройки стека для всех логгеров.
+func SetStacktraceOptions(opts StacktraceOptions) {
+	if opts.Depth <= 0 {
+		opts.Depth = DefaultStacktraceOptions.Depth
+	}
+	stacktraceOptions.Store(&opts)
+}
+
+// GetStacktraceOptions возвращает текущие настройки стека.
+func GetStacktraceOptions() StacktraceOptions {
+	if opts := stacktraceOptions.Load(); opts != nil {
+		return *opts
+	}
+	return DefaultStacktraceOptions
+}
@@ -0,0 +1,120 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/vsysa/logging"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sync/atomic"
+	"testing"
+)
+
+// syncCounter — приемник, считающий вызовы Sync.
+type syncCounter struct {
+	bytes.Buffer
+	syncs atomic.Int32
+}
+
+func (w *syncCounter) Sync() error {
+	w.syncs.Add(1)
+	return nil
+}
+
+func TestLevelFactory_SharedLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		factory LevelFactory
+	}{
+		{"ZapLoggerFactory", NewZapLoggerFactory(NewZapLoggerDefault())},
+		{"LogrusLoggerFactory", &LogrusLoggerFactory{}},
+		{"SlogLoggerFactory", NewSlogLoggerFactory(NewSlogLoggerDefault())},
+		{"ZerologLoggerFactory", NewZerologLoggerFactory(NewZerologLoggerDefault())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := tt.factory.CreateLogger()
+			second := tt.factory.CreateLogger()
+
+			tt.factory.SetLevel(logging.WarnLevel)
+			assert.Equal(t, logging.WarnLevel, tt.factory.GetLevel())
+			assert.Equal(t, logging.WarnLevel, first.GetLevel(), "Created loggers should follow factory level")
+
+			second.SetLevel(logging.DebugLevel)
+			assert.Equal(t, logging.WarnLevel, first.GetLevel(), "Logger override should not affect siblings")
+			assert.Equal(t, logging.WarnLevel, tt.factory.GetLevel(), "Logger override should not affect factory")
+		})
+	}
+}
+
+func TestFactory_Routes(t *testing.T) {
+	zapOut, zapErr := &bytes.Buffer{}, &bytes.Buffer{}
+	logrusOut, logrusErr := &bytes.Buffer{}, &bytes.Buffer{}
+	routes := func(out, errOut *bytes.Buffer) logging.Routes {
+		return logging.Routes{
+			{Level: logging.TraceLevel, Writer: out},
+			{Level: logging.ErrorLevel, Writer: errOut},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		factory     LoggerFactory
+		out, errOut *bytes.Buffer
+	}{
+		{"ZapLoggerFactory", NewZapLoggerFactory(NewZapLoggerWithRoutes(routes(zapOut, zapErr))), zapOut, zapErr},
+		{"LogrusLoggerFactory", (&LogrusLoggerFactory{}).SetRoutes(routes(logrusOut, logrusErr)), logrusOut, logrusErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := tt.factory.CreateLogger()
+			logger.Warn("warn message")
+			logger.Error("error message")
+
+			assert.Contains(t, tt.out.String(), "warn message")
+			assert.NotContains(t, tt.out.String(), "error message")
+			assert.Contains(t, tt.errOut.String(), "error message")
+			assert.NotContains(t, tt.errOut.String(), "warn message")
+		})
+	}
+}
+
+func TestFactory_CloseAndShutdown(t *testing.T) {
+	out := &syncCounter{}
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	factory := NewZapLoggerFactory(zap.New(zapcore.NewCore(encoder, out, zap.DebugLevel)), zap.NewAtomicLevelAt(zap.DebugLevel))
+
+	assert.NoError(t, logging.Shutdown(context.Background()))
+	assert.Zero(t, out.syncs.Load(), "Factory without loggers should not be synced")
+
+	var syncedBeforeExit int32
+	factory.SetExitFunc(func(int) { syncedBeforeExit = out.syncs.Load() })
+	factory.CreateLogger().Fatal("fatal")
+	assert.NotZero(t, syncedBeforeExit, "Fatal should flush before exit")
+
+	syncs := out.syncs.Load()
+	assert.NoError(t, logging.Shutdown(context.Background()))
+	assert.Equal(t, syncs+1, out.syncs.Load(), "Shutdown should sync loggers created through the factory")
+
+	assert.NoError(t, factory.Close(context.Background()))
+	assert.Equal(t, syncs+2, out.syncs.Load())
+
+	assert.NoError(t, logging.Shutdown(context.Background()))
+	assert.Equal(t, syncs+2, out.syncs.Load(), "Closed factory should leave Shutdown")
+}
+
+func TestFactory_CloseDefaults(t *testing.T) {
+	factories := []LoggerFactory{
+		NewZapLoggerFactory(NewZapLoggerDefault()),
+		&LogrusLoggerFactory{},
+		NewSlogLoggerFactory(NewSlogLoggerDefault()),
+		NewZerologLoggerFactory(NewZerologLoggerDefault()),
+	}
+	for _, f := range factories {
+		f.CreateLogger().Info("before close")
+		assert.NoError(t, f.Close(context.Background()), "Syncing stdout and stderr should not fail")
+	}
+}
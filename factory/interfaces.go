@@ -1,9 +1,84 @@
 package factory
 
 import (
+	"context"
 	"github.com/vsysa/logging"
+	"sync"
 )
 
 type LoggerFactory interface {
 	CreateLogger() logging.Logger
+	// Close сбрасывает вывод логгеров фабрики, ожидая не дольше, чем позволяет ctx, и
+	// исключает фабрику из logging.Shutdown. Созданные логгеры продолжают работать.
+	Close(ctx context.Context) error
+}
+
+// LevelFactory — фабрика, логгеры которой наследуют общий уровень: SetLevel фабрики
+// меняет уровень всех созданных ею логгеров, кроме тех, кому задан собственный.
+type LevelFactory interface {
+	LoggerFactory
+	GetLevel() logging.Level
+	SetLevel(level logging.Level)
+}
+
+// sharedRoot хранит корневой логгер фабрики. CreateLogger возвращает его клоны,
+// поэтому созданные логгеры наследуют его уровень. При создании корневой логгер
+// регистрируется в logging.Shutdown.
+type sharedRoot struct {
+	mu         sync.Mutex
+	root       logging.Logger
+	unregister func()
+}
+
+func (s *sharedRoot) get(create func() logging.Logger) logging.Logger {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.root == nil {
+		s.root = create()
+		s.unregister = logging.OnShutdown(s.sync)
+	}
+	return s.root
+}
+
+// sync сбрасывает вывод корневого логгера, если он создан. Sync бэкенда может
+// блокироваться, поэтому по завершении ctx sync возвращает ctx.Err(), не дожидаясь его.
+func (s *sharedRoot) sync(ctx context.Context) error {
+	s.mu.Lock()
+	root := s.root
+	s.mu.Unlock()
+	if root == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- root.Sync()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close реализует LoggerFactory.Close.
+func (s *sharedRoot) close(ctx context.Context) error {
+	s.mu.Lock()
+	unregister := s.unregister
+	s.unregister = nil
+	s.mu.Unlock()
+	if unregister != nil {
+		unregister()
+	}
+	return s.sync(ctx)
+}
+
+// clone создает логгер для CreateLogger и применяет к нему exitFunc, если он задан.
+func (s *sharedRoot) clone(create func() logging.Logger, exitFunc logging.ExitFunc) logging.Logger {
+	logger := s.get(create).Clone()
+	if setter, ok := logger.(logging.ExitFuncSetter); ok && exitFunc != nil {
+		setter.SetExitFunc(exitFunc)
+	}
+	return logger
 }
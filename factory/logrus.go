@@ -1,15 +1,51 @@
 package factory
 
 import (
+	"context"
 	"github.com/vsysa/logging"
 	"github.com/vsysa/logging/logger/logruslog"
 )
 
 type LogrusLoggerFactory struct {
+	routes   logging.Routes
+	exitFunc logging.ExitFunc
+	shared   sharedRoot
 }
 
 func (r *LogrusLoggerFactory) CreateLogger() logging.Logger {
+	return r.shared.clone(r.createRoot, r.exitFunc)
+}
+
+func (r *LogrusLoggerFactory) Close(ctx context.Context) error {
+	return r.shared.close(ctx)
+}
+
+func (r *LogrusLoggerFactory) GetLevel() logging.Level {
+	return r.shared.get(r.createRoot).GetLevel()
+}
+
+func (r *LogrusLoggerFactory) SetLevel(level logging.Level) {
+	r.shared.get(r.createRoot).SetLevel(level)
+}
+
+func (r *LogrusLoggerFactory) createRoot() logging.Logger {
+	if r.routes != nil {
+		return logruslog.NewLogrusLoggerWithRoutes(r.routes)
+	}
 	return logruslog.NewLogrusLogger()
 }
 
-var _ LoggerFactory = &LogrusLoggerFactory{}
+// SetRoutes задает маршруты вывода по уровню вместо logging.DefaultRoutes. Действует,
+// если вызван до создания первого логгера.
+func (r *LogrusLoggerFactory) SetRoutes(routes logging.Routes) *LogrusLoggerFactory {
+	r.routes = routes
+	return r
+}
+
+// SetExitFunc задает logging.ExitFunc для создаваемых логгеров.
+func (r *LogrusLoggerFactory) SetExitFunc(exitFunc logging.ExitFunc) *LogrusLoggerFactory {
+	r.exitFunc = exitFunc
+	return r
+}
+
+var _ LevelFactory = &LogrusLoggerFactory{}
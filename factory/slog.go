@@ -0,0 +1,66 @@
+package factory
+
+import (
+	"context"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/sloglog"
+	"log/slog"
+	"os"
+)
+
+type SlogLoggerFactory struct {
+	handler  slog.Handler
+	levelVar *slog.LevelVar
+	exitFunc logging.ExitFunc
+	shared   sharedRoot
+}
+
+func NewSlogLoggerFactory(handler slog.Handler, levelVar *slog.LevelVar) *SlogLoggerFactory {
+	return &SlogLoggerFactory{handler: handler, levelVar: levelVar}
+}
+
+func NewSlogLoggerDefault() (slog.Handler, *slog.LevelVar) {
+	levelVar := &slog.LevelVar{}
+	levelVar.Set(slog.LevelDebug)
+
+	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       levelVar,
+		ReplaceAttr: sloglog.ReplaceLevelNames,
+	})
+
+	return handler, levelVar
+}
+
+func (r *SlogLoggerFactory) CreateLogger() logging.Logger {
+	return r.shared.clone(r.createRoot, r.exitFunc)
+}
+
+func (r *SlogLoggerFactory) Close(ctx context.Context) error {
+	return r.shared.close(ctx)
+}
+
+func (r *SlogLoggerFactory) GetLevel() logging.Level {
+	return r.shared.get(r.createRoot).GetLevel()
+}
+
+func (r *SlogLoggerFactory) SetLevel(level logging.Level) {
+	r.shared.get(r.createRoot).SetLevel(level)
+}
+
+func (r *SlogLoggerFactory) createRoot() logging.Logger {
+	handler := r.handler
+	levelVar := r.levelVar
+	if handler == nil {
+		handler, levelVar = NewSlogLoggerDefault()
+	}
+	return sloglog.NewSlogLogger(handler, levelVar)
+}
+
+// SetExitFunc задает logging.ExitFunc для создаваемых логгеров.
+func (r *SlogLoggerFactory) SetExitFunc(exitFunc logging.ExitFunc) *SlogLoggerFactory {
+	r.exitFunc = exitFunc
+	return r
+}
+
+var _ LevelFactory = &SlogLoggerFactory{}
@@ -1,38 +1,60 @@
 package factory
 
 import (
+	"context"
 	"github.com/vsysa/logging"
 	"github.com/vsysa/logging/logger/zaplog"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"os"
 )
 
 type ZapLoggerFactory struct {
 	zapLogger   *zap.Logger
 	atomicLevel zap.AtomicLevel
+	exitFunc    logging.ExitFunc
+	shared      sharedRoot
 }
 
 func NewZapLoggerFactory(zapLogger *zap.Logger, atomicLevel zap.AtomicLevel) *ZapLoggerFactory {
 	return &ZapLoggerFactory{zapLogger: zapLogger, atomicLevel: atomicLevel}
 }
 
+// NewZapLoggerDefault создает цветной консольный zap.Logger с маршрутами logging.DefaultRoutes:
+// Warn и выше пишутся в stderr, остальное — в stdout.
 func NewZapLoggerDefault() (*zap.Logger, zap.AtomicLevel) {
+	return NewZapLoggerWithRoutes(logging.DefaultRoutes())
+}
+
+// NewZapLoggerWithRoutes — NewZapLoggerDefault с собственными маршрутами вывода по уровню.
+func NewZapLoggerWithRoutes(routes logging.Routes) (*zap.Logger, zap.AtomicLevel) {
 	// Настройка цветного вывода для консоли
 	encoderConfig := zap.NewDevelopmentEncoderConfig()
-	encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // CapitalColorLevelEncoder добавляет цвет в зависимости от уровня
+	encoderConfig.EncodeLevel = zaplog.CapitalColorLevelEncoder // CapitalColorLevelEncoder добавляет цвет в зависимости от уровня
+	encoderConfig.FunctionKey = "F"
 	atomicLevel := zap.NewAtomicLevelAt(zap.DebugLevel)
 
-	consoleCore := zapcore.NewCore(
-		zapcore.NewConsoleEncoder(encoderConfig),
-		zapcore.AddSync(zapcore.Lock(os.Stdout)),
-		atomicLevel,
-	)
+	consoleCore := zaplog.NewRoutingCore(zapcore.NewConsoleEncoder(encoderConfig), routes, atomicLevel)
 
-	return zap.New(consoleCore, zap.AddCaller(), zap.AddCallerSkip(2)), atomicLevel
+	return zap.New(consoleCore), atomicLevel
 }
 
 func (r *ZapLoggerFactory) CreateLogger() logging.Logger {
+	return r.shared.clone(r.createRoot, r.exitFunc)
+}
+
+func (r *ZapLoggerFactory) Close(ctx context.Context) error {
+	return r.shared.close(ctx)
+}
+
+func (r *ZapLoggerFactory) GetLevel() logging.Level {
+	return r.shared.get(r.createRoot).GetLevel()
+}
+
+func (r *ZapLoggerFactory) SetLevel(level logging.Level) {
+	r.shared.get(r.createRoot).SetLevel(level)
+}
+
+func (r *ZapLoggerFactory) createRoot() logging.Logger {
 	zl := r.zapLogger
 	zal := r.atomicLevel
 	if zl == nil {
@@ -41,4 +63,10 @@ func (r *ZapLoggerFactory) CreateLogger() logging.Logger {
 	return zaplog.NewZapLogger(zl, zal)
 }
 
-var _ LoggerFactory = &ZapLoggerFactory{}
+// SetExitFunc задает logging.ExitFunc для создаваемых логгеров.
+func (r *ZapLoggerFactory) SetExitFunc(exitFunc logging.ExitFunc) *ZapLoggerFactory {
+	r.exitFunc = exitFunc
+	return r
+}
+
+var _ LevelFactory = &ZapLoggerFactory{}
@@ -0,0 +1,60 @@
+package factory
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/zerologlog"
+	"os"
+)
+
+type ZerologLoggerFactory struct {
+	zerologLogger *zerolog.Logger
+	atomicLevel   *zerologlog.AtomicLevel
+	exitFunc      logging.ExitFunc
+	shared        sharedRoot
+}
+
+func NewZerologLoggerFactory(zerologLogger *zerolog.Logger, atomicLevel *zerologlog.AtomicLevel) *ZerologLoggerFactory {
+	return &ZerologLoggerFactory{zerologLogger: zerologLogger, atomicLevel: atomicLevel}
+}
+
+func NewZerologLoggerDefault() (*zerolog.Logger, *zerologlog.AtomicLevel) {
+	l := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: "2006-01-02T15:04:05.999"}).
+		With().Timestamp().Logger()
+
+	return &l, zerologlog.NewAtomicLevel(zerolog.DebugLevel)
+}
+
+func (r *ZerologLoggerFactory) CreateLogger() logging.Logger {
+	return r.shared.clone(r.createRoot, r.exitFunc)
+}
+
+func (r *ZerologLoggerFactory) Close(ctx context.Context) error {
+	return r.shared.close(ctx)
+}
+
+func (r *ZerologLoggerFactory) GetLevel() logging.Level {
+	return r.shared.get(r.createRoot).GetLevel()
+}
+
+func (r *ZerologLoggerFactory) SetLevel(level logging.Level) {
+	r.shared.get(r.createRoot).SetLevel(level)
+}
+
+func (r *ZerologLoggerFactory) createRoot() logging.Logger {
+	zl := r.zerologLogger
+	al := r.atomicLevel
+	if zl == nil {
+		zl, al = NewZerologLoggerDefault()
+	}
+	return zerologlog.NewZerologLogger(zl, al)
+}
+
+// SetExitFunc задает logging.ExitFunc для создаваемых логгеров.
+func (r *ZerologLoggerFactory) SetExitFunc(exitFunc logging.ExitFunc) *ZerologLoggerFactory {
+	r.exitFunc = exitFunc
+	return r
+}
+
+var _ LevelFactory = &ZerologLoggerFactory{}
@@ -0,0 +1,14 @@
+package logging
+
+// ExitFunc завершает процесс после Fatal-записи.
+//
+// Контракт Fatal, FatalCatch и Fatalw одинаков для всех бэкендов: запись на уровне
+// Fatal, сброс буферов бэкенда, затем вызов ExitFunc с кодом 1. По умолчанию это
+// os.Exit (у testlog.TestLogger — пустая функция); подмена позволяет проверять Fatal-пути
+// в тестах, не завершая тестовый бинарник.
+type ExitFunc func(code int)
+
+// ExitFuncSetter реализуют логгеры, у которых можно подменить ExitFunc.
+type ExitFuncSetter interface {
+	SetExitFunc(exitFunc ExitFunc)
+}
@@ -0,0 +1,19 @@
+package helper
+
+import "runtime"
+
+// CallerPC возвращает pc кадра на skip выше функции, вызвавшей CallerPC, или 0,
+// если стек короче.
+func CallerPC(skip int) uintptr {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return 0
+	}
+	return pcs[0]
+}
+
+// CallerFrame возвращает кадр для pc, полученного из CallerPC или runtime.Callers.
+func CallerFrame(pc uintptr) runtime.Frame {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return frame
+}
@@ -0,0 +1,91 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/vsysa/logging"
+)
+
+// maxErrorCauses ограничивает число причин, чтобы зацикленная цепочка Unwrap не повесила запись.
+const maxErrorCauses = 32
+
+// ErrorFields возвращает поля записи уровня level для err: поля ошибок цепочки, реализующих
+// logging.LogFielder, logging.ErrorKey со значением ErrorValue(err) и, если позволяют
+// logging.StacktraceOptions, logging.StacktraceKey. Стек берется из err или, если его там
+// нет, снимается с места вызова: ErrorFields должен вызываться прямо из публичного метода
+// логгера, а skip — число дополнительных кадров над ним (см. Logger.WithCallerSkip).
+// Сбор полей дорог, поэтому логгер вызывает ErrorFields только для включенного level.
+// Для nil возвращается nil.
+func ErrorFields(err error, level logging.Level, skip int) map[string]interface{} {
+	if err == nil {
+		return nil
+	}
+	fields := errorLogFields(err)
+	delete(fields, logging.StacktraceKey)
+	fields[logging.ErrorKey] = ErrorValue(err)
+	// пропускаются ErrorFields и публичный метод логгера
+	if stack := stacktrace(err, level, skip+2); stack != "" {
+		fields[logging.StacktraceKey] = stack
+	}
+	return fields
+}
+
+// ErrorValue описывает err объектом с текстом, конкретным типом и причинами. Причины
+// собираются обходом в глубину через Unwrap() error и Unwrap() []error (errors.Join)
+// и перечисляются плоским списком без самой err.
+func ErrorValue(err error) map[string]interface{} {
+	value := describeError(err)
+	var causes []interface{}
+	for _, cause := range unwrapAll(err) {
+		causes = append(causes, describeError(cause))
+	}
+	if len(causes) > 0 {
+		value[logging.ErrorCausesKey] = causes
+	}
+	return value
+}
+
+func describeError(err error) map[string]interface{} {
+	return map[string]interface{}{
+		logging.ErrorMessageKey: err.Error(),
+		logging.ErrorTypeKey:    fmt.Sprintf("%T", err),
+	}
+}
+
+// unwrapAll возвращает все ошибки, обернутые err, в порядке обхода в глубину.
+func unwrapAll(err error) []error {
+	var causes []error
+	var walk func(err error)
+	walk = func(err error) {
+		var wrapped []error
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			wrapped = []error{e.Unwrap()}
+		case interface{ Unwrap() []error }:
+			wrapped = e.Unwrap()
+		}
+		for _, cause := range wrapped {
+			if cause == nil || len(causes) >= maxErrorCauses {
+				continue
+			}
+			causes = append(causes, cause)
+			walk(cause)
+		}
+	}
+	walk(err)
+	return causes
+}
+
+// errorLogFields собирает поля ошибок цепочки err, реализующих logging.LogFielder.
+// Цепочка обходится от самой глубокой ошибки, чтобы поля внешних ошибок перекрывали внутренние.
+func errorLogFields(err error) map[string]interface{} {
+	fields := make(map[string]interface{})
+	chain := append([]error{err}, unwrapAll(err)...)
+	for i := len(chain) - 1; i >= 0; i-- {
+		if fielder, ok := chain[i].(logging.LogFielder); ok {
+			for key, value := range fielder.LogFields() {
+				fields[key] = NormalizeValue(value)
+			}
+		}
+	}
+	return fields
+}
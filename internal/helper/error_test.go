@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/vsysa/logging"
+	"io/fs"
+	"testing"
+)
+
+func TestErrorValue(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+	err := fmt.Errorf("load config: %w", errors.Join(pathErr, errors.New("fallback failed")))
+
+	value := ErrorValue(err)
+
+	assert.Equal(t, err.Error(), value[logging.ErrorMessageKey])
+	assert.Equal(t, "*fmt.wrapError", value[logging.ErrorTypeKey])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{logging.ErrorMessageKey: errors.Join(pathErr, errors.New("fallback failed")).Error(), logging.ErrorTypeKey: "*errors.joinError"},
+		map[string]interface{}{logging.ErrorMessageKey: pathErr.Error(), logging.ErrorTypeKey: "*fs.PathError"},
+		map[string]interface{}{logging.ErrorMessageKey: fs.ErrNotExist.Error(), logging.ErrorTypeKey: "*errors.errorString"},
+		map[string]interface{}{logging.ErrorMessageKey: "fallback failed", logging.ErrorTypeKey: "*errors.errorString"},
+	}, value[logging.ErrorCausesKey])
+}
+
+func TestErrorValue_NoCauses(t *testing.T) {
+	value := ErrorValue(errors.New("plain"))
+	assert.Equal(t, map[string]interface{}{logging.ErrorMessageKey: "plain", logging.ErrorTypeKey: "*errors.errorString"}, value)
+}
+
+type loopError struct{}
+
+func (e *loopError) Error() string { return "loop" }
+func (e *loopError) Unwrap() error { return e }
+
+func TestErrorValue_Cycle(t *testing.T) {
+	value := ErrorValue(&loopError{})
+	assert.Len(t, value[logging.ErrorCausesKey], maxErrorCauses, "Cyclic chain should be truncated")
+}
+
+func TestErrorFields(t *testing.T) {
+	assert.Nil(t, ErrorFields(nil, logging.ErrorLevel, 0))
+	assert.Contains(t, ErrorFields(errors.New("boom"), logging.ErrorLevel, 0), logging.ErrorKey)
+}
+
+type orderError struct {
+	orderID  int
+	tenantID string
+	err      error
+}
+
+func (e *orderError) Error() string { return "order failed" }
+
+func (e *orderError) Unwrap() error { return e.err }
+
+func (e *orderError) LogFields() map[string]any {
+	return map[string]any{"order_id": e.orderID, "tenant_id": e.tenantID, logging.ErrorKey: "ignored"}
+}
+
+func TestErrorFields_LogFielder(t *testing.T) {
+	inner := &orderError{orderID: 1, tenantID: "inner"}
+	outer := &orderError{orderID: 2, tenantID: "outer", err: errors.Join(errors.New("other"), inner)}
+	err := fmt.Errorf("checkout: %w", outer)
+
+	fields := ErrorFields(err, logging.InfoLevel, 0)
+
+	assert.Equal(t, 2, fields["order_id"], "Outer error fields should win")
+	assert.Equal(t, "outer", fields["tenant_id"])
+	assert.IsType(t, map[string]interface{}{}, fields[logging.ErrorKey], "Error field should not be overridden")
+
+	fields = ErrorFields(fmt.Errorf("retry: %w", inner), logging.InfoLevel, 0)
+	assert.Equal(t, 1, fields["order_id"], "Fields should be found anywhere in the chain")
+}
@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// LevelFloor хранит уровень бэкенда отдельно от уровня, который проверяют его cores или
+// handler (например zap.AtomicLevel или slog.LevelVar).
+//
+// Клон с собственным уровнем ниже уровня бэкенда опускает проверяемый уровень через Lower
+// только на время проверки своей записи, а фильтрацию по уровню каждый логгер выполняет сам.
+// Так запись клона проходит обычную проверку бэкенда, собственные уровни cores, handler и
+// хуков по-прежнему действуют, а после проверки проверяемый уровень возвращается к уровню
+// бэкенда, как только его не опускает ни одна другая запись.
+type LevelFloor[L cmp.Ordered] struct {
+	get func() L
+	set func(L)
+
+	mu sync.Mutex
+	// lowered — уровни записей, которые сейчас опускают проверяемый уровень
+	lowered []L
+	// level — уровень бэкенда, пока проверяемый уровень опущен; nil — они совпадают
+	level atomic.Pointer[L]
+}
+
+// NewLevelFloor создает LevelFloor над проверяемым уровнем с доступом через get и set.
+func NewLevelFloor[L cmp.Ordered](get func() L, set func(L)) *LevelFloor[L] {
+	return &LevelFloor[L]{get: get, set: set}
+}
+
+// Level возвращает уровень бэкенда без учета опускания.
+func (f *LevelFloor[L]) Level() L {
+	if level := f.level.Load(); level != nil {
+		return *level
+	}
+	return f.get()
+}
+
+// SetLevel задает уровень бэкенда. Пока проверяемый уровень опущен, он остается не выше
+// уровней, которые его опускают.
+func (f *LevelFloor[L]) SetLevel(level L) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.lowered) == 0 {
+		f.set(level)
+		f.level.Store(nil)
+		return
+	}
+	f.level.Store(&level)
+	f.set(min(level, slices.Min(f.lowered)))
+}
+
+// Lower опускает проверяемый уровень до level, если он выше уровня бэкенда, и возвращает
+// функцию, которая снимает это опускание. Ее нужно вызвать сразу после проверки записи.
+func (f *LevelFloor[L]) Lower(level L) (restore func()) {
+	if f.level.Load() == nil && level >= f.get() {
+		return func() {}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	backend := f.Level()
+	if level >= backend {
+		return func() {}
+	}
+	if len(f.lowered) == 0 {
+		f.level.Store(&backend)
+	}
+	f.lowered = append(f.lowered, level)
+	f.set(slices.Min(f.lowered))
+	return func() { f.raise(level) }
+}
+
+// raise снимает опускание до level, сделанное Lower.
+func (f *LevelFloor[L]) raise(level L) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	i := slices.Index(f.lowered, level)
+	f.lowered = slices.Delete(f.lowered, i, i+1)
+	if len(f.lowered) > 0 {
+		f.set(min(f.Level(), slices.Min(f.lowered)))
+		return
+	}
+	f.set(f.Level())
+	f.level.Store(nil)
+}
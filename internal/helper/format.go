@@ -0,0 +1,41 @@
+package helper
+
+import "fmt"
+
+// BadKey — ключ для значения без пары или для нестрокового ключа, как в log/slog.
+const BadKey = "!BADKEY"
+
+// FormatMessage форматирует сообщение через fmt.Sprintf только при наличии аргументов,
+// чтобы литеральный % в сообщении без аргументов не искажался.
+func FormatMessage(message string, a []any) string {
+	if len(a) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, a...)
+}
+
+// KeysAndValues собирает поля из чередующихся ключей и значений.
+// Ключи должны быть строками; значение без ключа сохраняется под BadKey.
+func KeysAndValues(keysAndValues []any) map[string]interface{} {
+	fields := make(map[string]interface{}, len(keysAndValues)/2)
+	for i := 0; i < len(keysAndValues); {
+		key, ok := keysAndValues[i].(string)
+		if !ok || i+1 == len(keysAndValues) {
+			fields[BadKey] = NormalizeValue(keysAndValues[i])
+			i++
+			continue
+		}
+		fields[key] = NormalizeValue(keysAndValues[i+1])
+		i += 2
+	}
+	return fields
+}
+
+// MergeContext возвращает копию context, дополненную полями fields.
+func MergeContext(context, fields map[string]interface{}) map[string]interface{} {
+	merged := CopyMapContext(context)
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return merged
+}
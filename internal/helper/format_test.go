@@ -0,0 +1,41 @@
+package helper
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFormatMessage(t *testing.T) {
+	assert.Equal(t, "100% done", FormatMessage("100% done", nil))
+	assert.Equal(t, "100% done", FormatMessage("%d%% done", []any{100}))
+}
+
+func TestKeysAndValues(t *testing.T) {
+	tests := []struct {
+		name string
+		kv   []any
+		want map[string]interface{}
+	}{
+		{
+			name: "Pairs",
+			kv:   []any{"id", 1, "ok", true},
+			want: map[string]interface{}{"id": 1, "ok": true},
+		},
+		{
+			name: "Dangling value",
+			kv:   []any{"id", 1, "orphan"},
+			want: map[string]interface{}{"id": 1, BadKey: "orphan"},
+		},
+		{
+			name: "Non-string key",
+			kv:   []any{42, "id", 1},
+			want: map[string]interface{}{BadKey: 42, "id": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, KeysAndValues(tt.kv))
+		})
+	}
+}
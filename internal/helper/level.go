@@ -0,0 +1,201 @@
+package helper
+
+import (
+	"context"
+	"github.com/vsysa/logging"
+	"sync/atomic"
+)
+
+// LevelNode — узел иерархии уровней клонированных логгеров.
+//
+// Корневой узел читает уровень из бэкенда через base. Дочерний узел наследует
+// действующий уровень родителя, пока для него не задан собственный уровень или
+// уровень не найден через lookup; собственный уровень не влияет ни на родителя,
+// ни на соседние узлы.
+type LevelNode[L any] struct {
+	parent   *LevelNode[L]
+	base     func() L
+	lookup   func() (L, bool)
+	override atomic.Pointer[L]
+}
+
+func NewLevelNode[L any](base func() L) *LevelNode[L] {
+	return &LevelNode[L]{base: base}
+}
+
+// Child создает дочерний узел, наследующий уровень n.
+func (n *LevelNode[L]) Child() *LevelNode[L] {
+	return &LevelNode[L]{parent: n}
+}
+
+// ChildWithLookup создает дочерний узел, который перед наследованием уровня n
+// проверяет lookup, например уровень по имени логгера.
+func (n *LevelNode[L]) ChildWithLookup(lookup func() (L, bool)) *LevelNode[L] {
+	return &LevelNode[L]{parent: n, lookup: lookup}
+}
+
+func (n *LevelNode[L]) IsRoot() bool {
+	return n.parent == nil
+}
+
+// Level возвращает действующий уровень узла.
+func (n *LevelNode[L]) Level() L {
+	if override := n.override.Load(); override != nil {
+		return *override
+	}
+	if n.lookup != nil {
+		if level, ok := n.lookup(); ok {
+			return level
+		}
+	}
+	if n.parent != nil {
+		return n.parent.Level()
+	}
+	return n.base()
+}
+
+func (n *LevelNode[L]) SetOverride(level L) {
+	n.override.Store(&level)
+}
+
+func (n *LevelNode[L]) ClearOverride() {
+	n.override.Store(nil)
+}
+
+func (n *LevelNode[L]) HasOverride() bool {
+	return n.override.Load() != nil
+}
+
+// NamedLevelLookup возвращает lookup для ChildWithLookup, который ищет уровень логгера
+// name в logging.NamedLevels и переводит его в уровень бэкенда через levelMap.
+func NamedLevelLookup[L any](name string, levelMap map[logging.Level]L) func() (L, bool) {
+	return func() (L, bool) {
+		level, ok := logging.NamedLevels.Lookup(name)
+		if !ok {
+			var zero L
+			return zero, false
+		}
+		backendLevel, ok := levelMap[level]
+		return backendLevel, ok
+	}
+}
+
+// ContextLevel возвращает уровень запроса из ctx (см. logging.LevelFromContext),
+// переведенный в уровень бэкенда через levelMap.
+func ContextLevel[L any](ctx context.Context, levelMap map[logging.Level]L) (L, bool) {
+	level, ok := logging.LevelFromContext(ctx)
+	if !ok {
+		var zero L
+		return zero, false
+	}
+	backendLevel, ok := levelMap[level]
+	return backendLevel, ok
+}
+
+// BackendLevel переводит level в уровень бэкенда через levelMap. Пользовательский уровень,
+// которого нет в levelMap, переводится как ближайший менее строгий из levelMap, например
+// Notice (14) -> Info, а уровень ниже всех в levelMap — как самый низкий из них.
+// Для незарегистрированного уровня ok = false.
+func BackendLevel[L any](levelMap map[logging.Level]L, level logging.Level) (L, bool) {
+	if backendLevel, ok := levelMap[level]; ok {
+		return backendLevel, true
+	}
+	if !level.Registered() || len(levelMap) == 0 {
+		var zero L
+		return zero, false
+	}
+
+	nearest, found := logging.Level(0), false
+	for loggingLevel := range levelMap {
+		if loggingLevel <= level && (!found || loggingLevel > nearest) {
+			nearest, found = loggingLevel, true
+		}
+	}
+	if !found {
+		for loggingLevel := range levelMap {
+			if !found || loggingLevel < nearest {
+				nearest, found = loggingLevel, true
+			}
+		}
+	}
+	return levelMap[nearest], true
+}
+
+// LevelFromBackend переводит уровень бэкенда в logging.Level. Для уровня, которого нет
+// в levelMap, возвращается ближайший менее строгий уровень, например zap DPanic -> Error.
+// Если в уровень бэкенда переводятся несколько уровней logging, например Error и Panic
+// в logrus, возвращается наименее строгий из них.
+// ascending указывает, что у бэкенда более строгий уровень имеет большее значение.
+func LevelFromBackend[L ~int | ~int8 | ~uint32](levelMap map[logging.Level]L, level L, ascending bool) logging.Level {
+	result, nearest, found := logging.TraceLevel, L(0), false
+	for loggingLevel, backendLevel := range levelMap {
+		passes, closer := backendLevel <= level, backendLevel > nearest
+		if !ascending {
+			passes, closer = backendLevel >= level, backendLevel < nearest
+		}
+		if !passes {
+			continue
+		}
+		if !found || closer || (backendLevel == nearest && loggingLevel < result) {
+			result, nearest, found = loggingLevel, backendLevel, true
+		}
+	}
+	return result
+}
+
+// NativeLevel сообщает, что у level есть собственный уровень бэкенда: level есть в levelMap
+// и обратно переводится в себя же (см. LevelFromBackend). Остальные уровни бэкенд пишет
+// на ближайшем менее строгом уровне, и их имя выводится отдельно.
+func NativeLevel[L ~int | ~int8 | ~uint32](levelMap map[logging.Level]L, level logging.Level, ascending bool) bool {
+	backendLevel, ok := levelMap[level]
+	return ok && LevelFromBackend(levelMap, backendLevel, ascending) == level
+}
+
+// LevelKeys возвращает уровни levelMap, переведенные в самих себя, для логгеров, которые
+// хранят уровень в единицах logging (см. NamedLevelLookup и ContextLevel).
+func LevelKeys[L any](levelMap map[logging.Level]L) map[logging.Level]logging.Level {
+	levels := make(map[logging.Level]logging.Level, len(levelMap))
+	for level := range levelMap {
+		levels[level] = level
+	}
+	return levels
+}
+
+// AliasedLevel — уровень бэкенда в единицах logging для бэкенда, в уровень которого
+// переводятся несколько уровней logging, например Error и Panic в logrus. Уровень,
+// заданный через SetLevel, запоминается, пока уровень бэкенда не изменится в обход
+// AliasedLevel; после этого Level переводит уровень бэкенда через LevelFromBackend.
+type AliasedLevel[L ~int | ~int8 | ~uint32] struct {
+	levelMap  map[logging.Level]L
+	ascending bool
+	get       func() L
+	set       func(L)
+	// level — уровень, заданный через SetLevel; 0 — не задан
+	level atomic.Int32
+}
+
+// NewAliasedLevel создает AliasedLevel над уровнем бэкенда с доступом через get и set.
+func NewAliasedLevel[L ~int | ~int8 | ~uint32](levelMap map[logging.Level]L, ascending bool, get func() L, set func(L)) *AliasedLevel[L] {
+	return &AliasedLevel[L]{levelMap: levelMap, ascending: ascending, get: get, set: set}
+}
+
+// Level возвращает уровень, заданный через SetLevel, или уровень бэкенда, если тот изменился.
+func (a *AliasedLevel[L]) Level() logging.Level {
+	backendLevel := a.get()
+	if level := logging.Level(a.level.Load()); level != 0 && a.levelMap[level] == backendLevel {
+		return level
+	}
+	return LevelFromBackend(a.levelMap, backendLevel, a.ascending)
+}
+
+// SetLevel задает уровень бэкенда для level. Для уровня, которого нет в levelMap,
+// возвращает false и ничего не меняет.
+func (a *AliasedLevel[L]) SetLevel(level logging.Level) bool {
+	backendLevel, ok := a.levelMap[level]
+	if !ok {
+		return false
+	}
+	a.level.Store(int32(level))
+	a.set(backendLevel)
+	return true
+}
@@ -0,0 +1,173 @@
+package helper
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/vsysa/logging"
+	"testing"
+)
+
+func TestLevelNode(t *testing.T) {
+	rootLevel := 12
+	root := NewLevelNode(func() int { return rootLevel })
+	child := root.Child()
+	sibling := root.Child()
+	grandchild := child.Child()
+
+	assert.True(t, root.IsRoot())
+	assert.False(t, child.IsRoot())
+	assert.Equal(t, 12, grandchild.Level(), "Child should inherit root level")
+
+	rootLevel = 16
+	assert.Equal(t, 16, child.Level(), "Child should follow root level live")
+
+	child.SetOverride(8)
+	assert.Equal(t, 8, child.Level())
+	assert.True(t, child.HasOverride())
+	assert.False(t, grandchild.HasOverride(), "Inherited level is not an override")
+	assert.Equal(t, 8, grandchild.Level(), "Grandchild should inherit child override")
+	assert.Equal(t, 16, root.Level(), "Override should not affect parent")
+	assert.Equal(t, 16, sibling.Level(), "Override should not affect siblings")
+
+	child.ClearOverride()
+	assert.False(t, child.HasOverride())
+	assert.Equal(t, 16, child.Level(), "Cleared override should inherit again")
+}
+
+func TestLevelNode_Lookup(t *testing.T) {
+	root := NewLevelNode(func() int { return 12 })
+	named := ""
+	child := root.ChildWithLookup(func() (int, bool) {
+		return 4, named == "db"
+	})
+	grandchild := child.Child()
+
+	assert.Equal(t, 12, grandchild.Level(), "Without lookup match the root level should be inherited")
+
+	named = "db"
+	assert.Equal(t, 4, grandchild.Level(), "Lookup level should be inherited")
+
+	child.SetOverride(16)
+	assert.Equal(t, 16, child.Level(), "Override should take precedence over lookup")
+}
+
+func TestLevelFromBackend(t *testing.T) {
+	ascending := map[logging.Level]int{
+		logging.TraceLevel: -2,
+		logging.DebugLevel: -1,
+		logging.InfoLevel:  0,
+		logging.ErrorLevel: 2,
+		logging.FatalLevel: 5,
+	}
+	assert.Equal(t, logging.InfoLevel, LevelFromBackend(ascending, 0, true))
+	assert.Equal(t, logging.ErrorLevel, LevelFromBackend(ascending, 3, true), "Unknown level should map to the nearest lower one")
+	assert.Equal(t, logging.TraceLevel, LevelFromBackend(ascending, -5, true))
+
+	descending := map[logging.Level]uint32{
+		logging.TraceLevel: 6,
+		logging.InfoLevel:  4,
+		logging.FatalLevel: 1,
+	}
+	assert.Equal(t, logging.InfoLevel, LevelFromBackend(descending, 4, false))
+	assert.Equal(t, logging.InfoLevel, LevelFromBackend(descending, 3, false))
+
+	aliased := map[logging.Level]uint32{
+		logging.InfoLevel:  4,
+		logging.ErrorLevel: 2,
+		logging.PanicLevel: 2,
+		logging.FatalLevel: 1,
+	}
+	assert.Equal(t, logging.ErrorLevel, LevelFromBackend(aliased, 2, false), "Shared backend level should map to the least severe level")
+	assert.True(t, NativeLevel(aliased, logging.ErrorLevel, false))
+	assert.False(t, NativeLevel(aliased, logging.PanicLevel, false))
+	assert.False(t, NativeLevel(aliased, logging.WarnLevel, false))
+}
+
+func TestAliasedLevel(t *testing.T) {
+	levelMap := map[logging.Level]uint32{
+		logging.InfoLevel:  4,
+		logging.ErrorLevel: 2,
+		logging.PanicLevel: 2,
+		logging.FatalLevel: 1,
+	}
+	backend := uint32(4)
+	level := NewAliasedLevel(levelMap, false, func() uint32 { return backend }, func(l uint32) { backend = l })
+	assert.Equal(t, logging.InfoLevel, level.Level())
+
+	assert.True(t, level.SetLevel(logging.PanicLevel))
+	assert.Equal(t, uint32(2), backend)
+	assert.Equal(t, logging.PanicLevel, level.Level(), "Requested level should be kept while the backend level matches")
+
+	backend = 1
+	assert.Equal(t, logging.FatalLevel, level.Level(), "External backend change should take precedence")
+
+	assert.True(t, level.SetLevel(logging.ErrorLevel))
+	assert.Equal(t, logging.ErrorLevel, level.Level())
+
+	assert.False(t, level.SetLevel(logging.WarnLevel))
+	assert.Equal(t, logging.ErrorLevel, level.Level(), "Unknown level should be ignored")
+}
+
+func TestContextLevel(t *testing.T) {
+	levelMap := map[logging.Level]int{logging.DebugLevel: -1, logging.InfoLevel: 0}
+
+	_, ok := ContextLevel(context.Background(), levelMap)
+	assert.False(t, ok)
+
+	level, ok := ContextLevel(logging.ContextWithLevel(context.Background(), logging.DebugLevel), levelMap)
+	assert.True(t, ok)
+	assert.Equal(t, -1, level)
+
+	_, ok = ContextLevel(logging.ContextWithLevel(context.Background(), logging.TraceLevel), levelMap)
+	assert.False(t, ok, "Level missing from levelMap should be ignored")
+}
+
+func TestBackendLevel(t *testing.T) {
+	levelMap := map[logging.Level]int{logging.DebugLevel: -1, logging.InfoLevel: 0, logging.WarnLevel: 1}
+	notice := logging.MustRegisterLevel("helper-notice", 14, logging.Cyan)
+	verbose := logging.MustRegisterLevel("helper-verbose", 2, logging.White)
+
+	level, ok := BackendLevel(levelMap, logging.WarnLevel)
+	assert.True(t, ok)
+	assert.Equal(t, 1, level)
+
+	level, ok = BackendLevel(levelMap, notice)
+	assert.True(t, ok)
+	assert.Equal(t, 0, level, "Custom level should map to the nearest less severe level")
+
+	level, ok = BackendLevel(levelMap, verbose)
+	assert.True(t, ok)
+	assert.Equal(t, -1, level, "Level below all known ones should map to the lowest")
+
+	_, ok = BackendLevel(levelMap, logging.Level(15))
+	assert.False(t, ok, "Unregistered level should be rejected")
+}
+
+func TestLevelFloor(t *testing.T) {
+	checked := 12
+	floor := NewLevelFloor(func() int { return checked }, func(level int) { checked = level })
+
+	floor.Lower(16)()
+	assert.Equal(t, 12, checked, "Lower should never raise the checked level")
+
+	restoreTrace := floor.Lower(4)
+	assert.Equal(t, 4, checked)
+	assert.Equal(t, 12, floor.Level(), "Backend level should survive Lower")
+
+	restoreDebug := floor.Lower(8)
+	assert.Equal(t, 4, checked, "Checked level should stay at the lowest active level")
+
+	restoreTrace()
+	assert.Equal(t, 8, checked, "Checked level should follow the remaining active levels")
+
+	floor.SetLevel(16)
+	assert.Equal(t, 16, floor.Level())
+	assert.Equal(t, 8, checked, "SetLevel should not raise the checked level above active ones")
+
+	restoreDebug()
+	assert.Equal(t, 16, checked, "Checked level should return to the backend level")
+	assert.Equal(t, 16, floor.Level())
+
+	checked = 20
+	assert.Equal(t, 20, floor.Level(), "Without Lower the checked level is the backend level")
+}
@@ -0,0 +1,18 @@
+package helper
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanFields возвращает поля traceID и spanID активного спана ctx или nil, если спана нет.
+func SpanFields(ctx context.Context) map[string]interface{} {
+	spanContext := trace.SpanFromContext(ctx).SpanContext()
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return map[string]interface{}{
+		"traceID": spanContext.TraceID().String(),
+		"spanID":  spanContext.SpanID().String(),
+	}
+}
@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"github.com/vsysa/logging"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// errorStack возвращает стек самой глубокой ошибки в цепочке err, у которой есть метод
+// StackTrace(), как в github.com/pkg/errors и github.com/cockroachdb/errors. Пакеты не
+// импортируются: подходит любой StackTrace(), возвращающий слайс с элементами вида uintptr.
+func errorStack(err error) ([]uintptr, bool) {
+	var pcs []uintptr
+	found := false
+	for _, e := range append([]error{err}, unwrapAll(err)...) {
+		method := reflect.ValueOf(e).MethodByName("StackTrace")
+		if !method.IsValid() {
+			continue
+		}
+		methodType := method.Type()
+		if methodType.NumIn() != 0 || methodType.NumOut() != 1 ||
+			methodType.Out(0).Kind() != reflect.Slice || methodType.Out(0).Elem().Kind() != reflect.Uintptr {
+			continue
+		}
+		frames := method.Call(nil)[0]
+		pcs = make([]uintptr, frames.Len())
+		for i := range pcs {
+			pcs[i] = uintptr(frames.Index(i).Uint())
+		}
+		found = true
+	}
+	return pcs, found
+}
+
+// callerStack возвращает стек вызывающего, пропуская skip кадров над функцией,
+// вызвавшей callerStack.
+func callerStack(skip, depth int) []uintptr {
+	pcs := make([]uintptr, depth)
+	return pcs[:runtime.Callers(skip+2, pcs)]
+}
+
+// formatStack выводит не больше depth кадров в формате zap: функция, а на следующей
+// строке с отступом файл и номер строки.
+func formatStack(pcs []uintptr, depth int) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for i := 0; i < depth; i++ {
+		frame, more := frames.Next()
+		if frame.PC != 0 {
+			if b.Len() > 0 {
+				b.WriteByte('\n')
+			}
+			b.WriteString(frame.Function)
+			b.WriteString("\n\t")
+			b.WriteString(frame.File)
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// stacktrace возвращает стек для записи уровня level или "", если уровень ниже
+// logging.StacktraceOptions.Level. skip считается от функции, вызвавшей stacktrace.
+func stacktrace(err error, level logging.Level, skip int) string {
+	opts := logging.GetStacktraceOptions()
+	if level < opts.Level {
+		return ""
+	}
+	pcs, ok := errorStack(err)
+	if !ok {
+		pcs = callerStack(skip+1, opts.Depth)
+	}
+	return formatStack(pcs, opts.Depth)
+}
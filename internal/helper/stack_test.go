@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// catch имитирует публичный метод логгера, из которого вызывается ErrorFields.
+func catch(err error, level logging.Level) map[string]interface{} {
+	return ErrorFields(err, level, 0)
+}
+
+func TestErrorFields_CallerStack(t *testing.T) {
+	fields := catch(errors.New("boom"), logging.ErrorLevel)
+
+	stack, ok := fields[logging.StacktraceKey].(string)
+	require.True(t, ok)
+	assert.True(t, strings.HasPrefix(stack, "github.com/vsysa/logging/internal/helper.TestErrorFields_CallerStack\n\t"),
+		"Stack should start at the logger caller, got:\n%s", stack)
+	assert.Contains(t, stack, "stack_test.go:")
+}
+
+type frame uintptr
+
+type stackError struct {
+	stack []frame
+}
+
+func (e *stackError) Error() string { return "with stack" }
+
+func (e *stackError) StackTrace() []frame { return e.stack }
+
+func newStackError() error {
+	pcs := make([]uintptr, 8)
+	n := runtime.Callers(1, pcs)
+	err := &stackError{}
+	for _, pc := range pcs[:n] {
+		err.stack = append(err.stack, frame(pc))
+	}
+	return err
+}
+
+func TestErrorFields_ErrorStack(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", newStackError())
+
+	stack := catch(err, logging.ErrorLevel)[logging.StacktraceKey].(string)
+
+	assert.True(t, strings.HasPrefix(stack, "github.com/vsysa/logging/internal/helper.newStackError\n\t"),
+		"Stack should be taken from the wrapped error, got:\n%s", stack)
+}
+
+func TestErrorFields_StacktraceOptions(t *testing.T) {
+	defer logging.SetStacktraceOptions(logging.DefaultStacktraceOptions)
+
+	logging.SetStacktraceOptions(logging.StacktraceOptions{Level: logging.FatalLevel, Depth: 1})
+	assert.NotContains(t, catch(errors.New("boom"), logging.ErrorLevel), logging.StacktraceKey, "Level below the minimum should have no stack")
+
+	stack := catch(errors.New("boom"), logging.FatalLevel)[logging.StacktraceKey].(string)
+	assert.Len(t, strings.Split(stack, "\n"), 2, "Depth should limit the number of frames")
+}
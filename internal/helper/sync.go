@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"errors"
+	"syscall"
+)
+
+// SyncError отбрасывает из err ошибки Sync терминалов и каналов: os.Stdout и os.Stderr
+// возвращают EINVAL или ENOTTY, хотя их вывод не буферизуется. Ошибки, объединенные
+// через errors.Join или multierr, проверяются по отдельности.
+func SyncError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, SyncError(e))
+		}
+		return errors.Join(errs...)
+	}
+	if errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTTY) {
+		return nil
+	}
+	return err
+}
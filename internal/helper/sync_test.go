@@ -0,0 +1,23 @@
+package helper
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"syscall"
+	"testing"
+)
+
+func TestSyncError(t *testing.T) {
+	stdoutErr := &fs.PathError{Op: "sync", Path: "/dev/stdout", Err: syscall.EINVAL}
+	diskErr := &fs.PathError{Op: "sync", Path: "app.log", Err: syscall.EIO}
+
+	assert.NoError(t, SyncError(nil))
+	assert.NoError(t, SyncError(stdoutErr))
+	assert.NoError(t, SyncError(&fs.PathError{Op: "sync", Path: "/dev/tty", Err: syscall.ENOTTY}))
+	assert.Equal(t, diskErr, SyncError(diskErr))
+
+	joined := SyncError(errors.Join(stdoutErr, diskErr))
+	assert.ErrorIs(t, joined, syscall.EIO, "Real errors should survive next to terminal ones")
+	assert.NotErrorIs(t, joined, syscall.EINVAL)
+}
@@ -0,0 +1,25 @@
+package helper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NormalizeValue приводит значение контекста к виду, который могут закодировать
+// структурированные энкодеры бэкендов (zap, logrus и т.д.).
+//
+// Значения сохраняются со своим исходным типом: числа, bool, строки, time.Duration,
+// ошибки, структуры, слайсы и мапы передаются в бэкенд как есть. Исключение —
+// типы, которые не представимы в JSON: функции, каналы, unsafe.Pointer и
+// комплексные числа. Они заменяются строкой в формате "%v".
+func NormalizeValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return fmt.Sprintf("%v", value)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,41 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNormalizeValue(t *testing.T) {
+	type payload struct {
+		ID int
+	}
+	err := errors.New("boom")
+	ch := make(chan int)
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{name: "nil", value: nil, want: nil},
+		{name: "int", value: 42, want: 42},
+		{name: "bool", value: true, want: true},
+		{name: "duration", value: time.Second, want: time.Second},
+		{name: "error", value: err, want: err},
+		{name: "struct", value: payload{ID: 1}, want: payload{ID: 1}},
+		{name: "slice", value: []int{1, 2}, want: []int{1, 2}},
+		{name: "complex", value: complex(1, 2), want: "(1+2i)"},
+		{name: "chan", value: ch, want: fmt.Sprintf("%v", ch)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeValue(tt.value))
+		})
+	}
+
+	assert.IsType(t, "", NormalizeValue(func() {}), "Functions should be converted to string")
+}
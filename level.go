@@ -1,6 +1,16 @@
 package logging
 
-type Level = int32
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Level int32
 
 const (
 	TraceLevel Level = 4
@@ -8,5 +18,207 @@ const (
 	InfoLevel  Level = 12
 	WarnLevel  Level = 16
 	ErrorLevel Level = 20
+	// PanicLevel — уровень Panic, PanicCatch и Recover. Как и в zap, он строже Error,
+	// но мягче Fatal.
+	PanicLevel Level = 22
 	FatalLevel Level = 24
 )
+
+// Color — цвет уровня в цветном выводе, код цвета текста ANSI.
+type Color uint8
+
+const (
+	NoColor Color = 0
+	Red     Color = 31
+	Green   Color = 32
+	Yellow  Color = 33
+	Blue    Color = 34
+	Magenta Color = 35
+	Cyan    Color = 36
+	White   Color = 37
+)
+
+// Wrap окрашивает text в цвет c. Для NoColor text возвращается без изменений.
+func (c Color) Wrap(text string) string {
+	if c == NoColor {
+		return text
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", uint8(c), text)
+}
+
+type levelInfo struct {
+	name  string
+	color Color
+}
+
+var (
+	levelsMu sync.RWMutex
+	levels   = map[Level]levelInfo{
+		TraceLevel: {"trace", Cyan},
+		DebugLevel: {"debug", Magenta},
+		InfoLevel:  {"info", Blue},
+		WarnLevel:  {"warn", Yellow},
+		ErrorLevel: {"error", Red},
+		PanicLevel: {"panic", Red},
+		FatalLevel: {"fatal", Red},
+	}
+)
+
+// RegisterLevel регистрирует пользовательский уровень, например Notice между Info и Warn:
+//
+//	var NoticeLevel = logging.MustRegisterLevel("notice", 14, logging.Cyan)
+//
+// После регистрации уровень разбирается ParseLevel по имени и числу и выводится бэкендами
+// под своим именем. Записи на нем пишутся через Log и Logw; порогом логгера (SetLevel)
+// пользовательский уровень быть не может. Имя и значение должны быть свободны, а значение
+// положительно: нулевой Level означает "не задан" (например в stdlog.Options.Level).
+func RegisterLevel(name string, value Level, color Color) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return 0, fmt.Errorf("empty logging level name")
+	}
+	if value <= 0 {
+		return 0, fmt.Errorf("invalid logging level value %d: must be positive", int32(value))
+	}
+	if _, err := strconv.ParseInt(name, 10, 32); err == nil || name == "warning" {
+		return 0, fmt.Errorf("invalid logging level name %q", name)
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+	if info, ok := levels[value]; ok {
+		return 0, fmt.Errorf("logging level %d is already registered as %q", int32(value), info.name)
+	}
+	for level, info := range levels {
+		if info.name == name {
+			return 0, fmt.Errorf("logging level %q is already registered as %d", name, int32(level))
+		}
+	}
+	levels[value] = levelInfo{name: name, color: color}
+	return value, nil
+}
+
+// MustRegisterLevel — RegisterLevel, который паникует при ошибке.
+func MustRegisterLevel(name string, value Level, color Color) Level {
+	level, err := RegisterLevel(name, value, color)
+	if err != nil {
+		panic(err)
+	}
+	return level
+}
+
+// Levels возвращает все зарегистрированные уровни, включая пользовательские, по возрастанию.
+func Levels() []Level {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	result := make([]Level, 0, len(levels))
+	for level := range levels {
+		result = append(result, level)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+func lookupLevel(level Level) (levelInfo, bool) {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	info, ok := levels[level]
+	return info, ok
+}
+
+// ParseLevel разбирает имя уровня без учета регистра ("debug", "WARN", "warning").
+// Также принимается числовое значение одного из уровней, в том числе пользовательских.
+func ParseLevel(text string) (Level, error) {
+	name := strings.ToLower(strings.TrimSpace(text))
+	if name == "warning" {
+		return WarnLevel, nil
+	}
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	for level, info := range levels {
+		if info.name == name {
+			return level, nil
+		}
+	}
+	if n, err := strconv.ParseInt(name, 10, 32); err == nil {
+		if _, ok := levels[Level(n)]; ok {
+			return Level(n), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown logging level %q", text)
+}
+
+// String возвращает имя уровня в нижнем регистре или "Level(N)" для неизвестного значения.
+func (l Level) String() string {
+	if info, ok := lookupLevel(l); ok {
+		return info.name
+	}
+	return fmt.Sprintf("Level(%d)", int32(l))
+}
+
+// Registered сообщает, что l — стандартный или зарегистрированный пользовательский уровень.
+func (l Level) Registered() bool {
+	_, ok := lookupLevel(l)
+	return ok
+}
+
+// Color возвращает цвет уровня или NoColor для неизвестного значения.
+func (l Level) Color() Color {
+	info, _ := lookupLevel(l)
+	return info.color
+}
+
+func (l Level) MarshalText() ([]byte, error) {
+	if _, ok := lookupLevel(l); !ok {
+		return nil, fmt.Errorf("unknown logging level %d", int32(l))
+	}
+	return []byte(l.String()), nil
+}
+
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// UnmarshalJSON принимает как строку ("debug"), так и число (8).
+func (l *Level) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		text = string(data)
+	}
+	return l.UnmarshalText([]byte(text))
+}
+
+func (l Level) MarshalYAML() (interface{}, error) {
+	text, err := l.MarshalText()
+	return string(text), err
+}
+
+func (l *Level) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	return l.UnmarshalText([]byte(text))
+}
+
+// Set реализует flag.Value.
+func (l *Level) Set(text string) error {
+	return l.UnmarshalText([]byte(text))
+}
+
+// LevelFlag объявляет флаг уровня в flag.CommandLine, например --log-level=warn.
+func LevelFlag(name string, defaultLevel Level, usage string) *Level {
+	level := defaultLevel
+	flag.Var(&level, name, usage)
+	return &level
+}
+
+var _ flag.Value = (*Level)(nil)
@@ -0,0 +1,130 @@
+package logging
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    Level
+		wantErr bool
+	}{
+		{text: "trace", want: TraceLevel},
+		{text: "DEBUG", want: DebugLevel},
+		{text: " Info ", want: InfoLevel},
+		{text: "warn", want: WarnLevel},
+		{text: "warning", want: WarnLevel},
+		{text: "error", want: ErrorLevel},
+		{text: "fatal", want: FatalLevel},
+		{text: "16", want: WarnLevel},
+		{text: "5", wantErr: true},
+		{text: "verbose", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseLevel(tt.text)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLevel_String(t *testing.T) {
+	assert.Equal(t, "warn", WarnLevel.String())
+	assert.Equal(t, "Level(5)", Level(5).String())
+}
+
+func TestLevel_JSON(t *testing.T) {
+	type config struct {
+		Level Level `json:"level"`
+	}
+
+	data, err := json.Marshal(config{Level: ErrorLevel})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"level":"error"}`, string(data))
+
+	var cfg config
+	require.NoError(t, json.Unmarshal([]byte(`{"level":"debug"}`), &cfg))
+	assert.Equal(t, DebugLevel, cfg.Level)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"level":20}`), &cfg))
+	assert.Equal(t, ErrorLevel, cfg.Level)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"level":"loud"}`), &cfg))
+}
+
+func TestLevel_YAML(t *testing.T) {
+	type config struct {
+		Level Level `yaml:"level"`
+	}
+
+	data, err := yaml.Marshal(config{Level: WarnLevel})
+	require.NoError(t, err)
+	assert.Equal(t, "level: warn\n", string(data))
+
+	var cfg config
+	require.NoError(t, yaml.Unmarshal([]byte("level: trace\n"), &cfg))
+	assert.Equal(t, TraceLevel, cfg.Level)
+}
+
+func TestLevel_Flag(t *testing.T) {
+	level := InfoLevel
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&level, "log-level", "")
+
+	require.NoError(t, fs.Parse([]string{"--log-level=warn"}))
+	assert.Equal(t, WarnLevel, level)
+	assert.Error(t, fs.Parse([]string{"--log-level=loud"}))
+}
+
+func TestRegisterLevel(t *testing.T) {
+	notice, err := RegisterLevel("Notice", 14, Cyan)
+	require.NoError(t, err)
+	assert.Equal(t, Level(14), notice)
+	assert.Equal(t, "notice", notice.String())
+	assert.Equal(t, Cyan, notice.Color())
+	assert.True(t, notice.Registered())
+
+	parsed, err := ParseLevel("NOTICE")
+	require.NoError(t, err)
+	assert.Equal(t, notice, parsed)
+	parsed, err = ParseLevel("14")
+	require.NoError(t, err)
+	assert.Equal(t, notice, parsed)
+
+	text, err := notice.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "notice", string(text))
+	assert.Contains(t, Levels(), notice)
+
+	_, err = RegisterLevel("notice", 15, Green)
+	assert.Error(t, err, "Name should be unique")
+	_, err = RegisterLevel("audit", InfoLevel, Green)
+	assert.Error(t, err, "Value should be unique")
+	_, err = RegisterLevel("warning", 15, Green)
+	assert.Error(t, err, "Alias of a standard level should be rejected")
+	_, err = RegisterLevel("", 15, Green)
+	assert.Error(t, err)
+	_, err = RegisterLevel("zero", 0, Green)
+	assert.Error(t, err, "Zero value means unset and should be rejected")
+	_, err = RegisterLevel("negative", -4, Green)
+	assert.Error(t, err, "Negative value should be rejected")
+	assert.False(t, Level(0).Registered())
+	assert.False(t, Level(15).Registered())
+}
+
+func TestColor_Wrap(t *testing.T) {
+	assert.Equal(t, "\x1b[31merror\x1b[0m", Red.Wrap("error"))
+	assert.Equal(t, "plain", NoColor.Wrap("plain"))
+}
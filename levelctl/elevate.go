@@ -0,0 +1,118 @@
+package levelctl
+
+import (
+	"github.com/vsysa/logging"
+	"sync"
+	"time"
+)
+
+var (
+	elevationsMu sync.Mutex
+	elevations   = make(map[Leveler]*Elevation)
+)
+
+// Elevation — временно измененный уровень target, который вернется к прежнему
+// по истечении срока или при вызове Cancel.
+type Elevation struct {
+	target   Leveler
+	logger   logging.Logger
+	previous logging.Level
+	// inherited — уровень target до первого вызова был унаследованным (см. LevelResetter)
+	inherited bool
+	level     logging.Level
+	deadline  time.Time
+	timer     *time.Timer
+}
+
+// ElevateLevel устанавливает target уровень level на время ttl, а затем возвращает прежний.
+//
+// Повторный вызов для того же target, пока уровень не вернулся, продлевает срок до
+// now+ttl (если он позже текущего) и возвращает ту же Elevation; прежним по-прежнему
+// считается уровень до первого вызова. Если за время действия уровень target изменили
+// вручную, по окончании он не трогается.
+//
+// Если target — LevelResetter без собственного уровня, по окончании собственный уровень
+// снимается через ResetLevel, и target снова следует за уровнем родителя.
+func ElevateLevel(target Leveler, level logging.Level, ttl time.Duration) *Elevation {
+	elevationsMu.Lock()
+	defer elevationsMu.Unlock()
+
+	deadline := time.Now().Add(ttl)
+	if e, ok := elevations[target]; ok {
+		if deadline.After(e.deadline) {
+			e.deadline = deadline
+		}
+		if level != e.level {
+			setLevelLogged(target, e.logger, target.GetLevel(), level, "until", e.deadline.Format(time.RFC3339))
+			e.level = level
+		}
+		return e
+	}
+
+	e := &Elevation{
+		target:    target,
+		logger:    loggerFor(target),
+		previous:  target.GetLevel(),
+		inherited: inheritsLevel(target),
+		level:     level,
+		deadline:  deadline,
+	}
+	setLevelLogged(target, e.logger, e.previous, level, "until", deadline.Format(time.RFC3339))
+	e.timer = time.AfterFunc(ttl, e.expire)
+	elevations[target] = e
+	return e
+}
+
+// Deadline возвращает момент, когда уровень вернется к прежнему.
+func (e *Elevation) Deadline() time.Time {
+	elevationsMu.Lock()
+	defer elevationsMu.Unlock()
+	return e.deadline
+}
+
+// Cancel сразу возвращает прежний уровень. Повторный вызов ничего не делает.
+func (e *Elevation) Cancel() {
+	elevationsMu.Lock()
+	defer elevationsMu.Unlock()
+	e.finish()
+}
+
+func (e *Elevation) expire() {
+	elevationsMu.Lock()
+	defer elevationsMu.Unlock()
+
+	// срок могли продлить, пока таймер ждал блокировку
+	if remaining := time.Until(e.deadline); remaining > 0 {
+		e.timer.Reset(remaining)
+		return
+	}
+	e.finish()
+}
+
+func (e *Elevation) finish() {
+	if elevations[e.target] != e {
+		return
+	}
+	delete(elevations, e.target)
+	e.timer.Stop()
+
+	current := e.target.GetLevel()
+	if current != e.level {
+		return
+	}
+	if resetter, ok := e.target.(LevelResetter); ok && e.inherited && resetter.HasLevelOverride() {
+		if current == e.previous {
+			resetter.ResetLevel()
+			return
+		}
+		changeLevelLogged(e.logger, current, e.previous, resetter.ResetLevel, "reason", "elevation ended")
+		return
+	}
+	setLevelLogged(e.target, e.logger, current, e.previous, "reason", "elevation ended")
+}
+
+// inheritsLevel сообщает, что target — LevelResetter без собственного уровня.
+func inheritsLevel(target Leveler) bool {
+	resetter, ok := target.(LevelResetter)
+	return ok && !resetter.HasLevelOverride()
+}
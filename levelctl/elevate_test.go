@@ -0,0 +1,85 @@
+package levelctl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/testlog"
+	"testing"
+	"time"
+)
+
+func TestElevateLevelReverts(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.WarnLevel)
+
+	ElevateLevel(logger, logging.DebugLevel, 20*time.Millisecond)
+	assert.Equal(t, logging.DebugLevel, logger.GetLevel())
+
+	require.Eventually(t, func() bool { return logger.GetLevel() == logging.WarnLevel },
+		time.Second, 5*time.Millisecond, "Level should be restored after the TTL")
+}
+
+func TestElevateLevelExtends(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.WarnLevel)
+
+	first := ElevateLevel(logger, logging.DebugLevel, 50*time.Millisecond)
+	second := ElevateLevel(logger, logging.TraceLevel, time.Hour)
+	defer second.Cancel()
+
+	assert.Same(t, first, second, "Repeated calls should reuse the active elevation")
+	assert.Equal(t, logging.TraceLevel, logger.GetLevel())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), second.Deadline(), time.Second)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, logging.TraceLevel, logger.GetLevel(), "Extended elevation should outlive the first TTL")
+}
+
+func TestElevateLevelCancel(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.ErrorLevel)
+
+	elevation := ElevateLevel(logger, logging.DebugLevel, time.Hour)
+	elevation.Cancel()
+	assert.Equal(t, logging.ErrorLevel, logger.GetLevel())
+
+	elevation.Cancel()
+	assert.Equal(t, logging.ErrorLevel, logger.GetLevel())
+
+	next := ElevateLevel(logger, logging.InfoLevel, time.Hour)
+	defer next.Cancel()
+	assert.NotSame(t, elevation, next, "A cancelled elevation should not be reused")
+	assert.Equal(t, logging.InfoLevel, logger.GetLevel())
+}
+
+func TestElevateLevelKeepsManualChange(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.ErrorLevel)
+
+	elevation := ElevateLevel(logger, logging.DebugLevel, time.Hour)
+	logger.SetLevel(logging.WarnLevel)
+	elevation.Cancel()
+
+	assert.Equal(t, logging.WarnLevel, logger.GetLevel(), "A level set during the elevation should be kept")
+}
+
+func TestElevateLevelResetsInheritedLevel(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.WarnLevel)
+	child := logger.Clone()
+
+	elevation := ElevateLevel(child, logging.DebugLevel, time.Hour)
+	assert.Equal(t, logging.DebugLevel, child.GetLevel())
+	elevation.Cancel()
+
+	assert.Equal(t, logging.WarnLevel, child.GetLevel())
+	logger.SetLevel(logging.ErrorLevel)
+	assert.Equal(t, logging.ErrorLevel, child.GetLevel(), "Child should follow the parent again after the elevation")
+
+	child.SetLevel(logging.InfoLevel)
+	elevation = ElevateLevel(child, logging.DebugLevel, time.Hour)
+	elevation.Cancel()
+	logger.SetLevel(logging.WarnLevel)
+	assert.Equal(t, logging.InfoLevel, child.GetLevel(), "Own level set before the elevation should be restored")
+}
@@ -0,0 +1,124 @@
+package levelctl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/vsysa/logging"
+	"net/http"
+	"strings"
+)
+
+// Handler — http.Handler для просмотра и изменения уровня во время работы.
+//
+//	GET            {"level":"info","names":{"db":"debug"}}
+//	PUT            {"level":"debug"} или форма level=debug — уровень target
+//	GET ?name=db   {"name":"db","level":"debug"} — уровень имени с учетом префиксов
+//	PUT ?name=db   {"level":"debug"} — уровень префикса в реестре именованных уровней
+//	DELETE ?name=db — удаляет уровень префикса
+//
+// Уровень, который target не принял, и пользовательский уровень для имени отклоняются
+// с кодом 422.
+type Handler struct {
+	target   Leveler
+	registry *logging.LevelRegistry
+}
+
+// NewHandler создает Handler для target и реестра logging.NamedLevels.
+func NewHandler(target Leveler) *Handler {
+	return &Handler{target: target, registry: logging.NamedLevels}
+}
+
+type levelPayload struct {
+	Name  string                   `json:"name,omitempty"`
+	Level *logging.Level           `json:"level,omitempty"`
+	Names map[string]logging.Level `json:"names,omitempty"`
+}
+
+type errorPayload struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	name := req.URL.Query().Get("name")
+
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		level, err := decodeLevel(req)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorPayload{Error: err.Error()})
+			return
+		}
+		if name != "" {
+			if !standardLevel(level) {
+				writeJSON(w, http.StatusUnprocessableEntity, errorPayload{Error: fmt.Sprintf("level %s can't be used as a named level", level)})
+				return
+			}
+			h.registry.Set(name, level)
+		} else {
+			h.target.SetLevel(level)
+			if applied := h.target.GetLevel(); applied != level {
+				writeJSON(w, http.StatusUnprocessableEntity, errorPayload{Error: fmt.Sprintf("level %s is not supported by the logger, level is %s", level, applied)})
+				return
+			}
+		}
+	case http.MethodDelete:
+		if name == "" {
+			writeJSON(w, http.StatusBadRequest, errorPayload{Error: "name is required"})
+			return
+		}
+		h.registry.Delete(name)
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut, http.MethodDelete}, ", "))
+		writeJSON(w, http.StatusMethodNotAllowed, errorPayload{Error: fmt.Sprintf("method %s not allowed", req.Method)})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.payload(name))
+}
+
+func (h *Handler) payload(name string) levelPayload {
+	if name == "" {
+		level := h.target.GetLevel()
+		return levelPayload{Level: &level, Names: h.registry.Levels()}
+	}
+
+	level, ok := h.registry.Lookup(name)
+	if !ok {
+		level = h.target.GetLevel()
+	}
+	return levelPayload{Name: name, Level: &level}
+}
+
+// standardLevel сообщает, что level — не пользовательский уровень. Бэкенды применяют
+// именованный уровень, только если он стандартный.
+func standardLevel(level logging.Level) bool {
+	switch level {
+	case logging.TraceLevel, logging.DebugLevel, logging.InfoLevel, logging.WarnLevel,
+		logging.ErrorLevel, logging.PanicLevel, logging.FatalLevel:
+		return true
+	}
+	return false
+}
+
+func decodeLevel(req *http.Request) (logging.Level, error) {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return logging.ParseLevel(req.FormValue("level"))
+	}
+
+	var payload levelPayload
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		return 0, fmt.Errorf("malformed request body: %w", err)
+	}
+	if payload.Level == nil {
+		return 0, errors.New("level is required")
+	}
+	return *payload.Level, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,103 @@
+package levelctl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/testlog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func serve(handler http.Handler, method, target, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	t.Cleanup(logging.NamedLevels.Reset)
+
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.InfoLevel)
+	handler := NewHandler(logger)
+
+	rec := serve(handler, http.MethodGet, "/", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"info"}`, rec.Body.String())
+
+	rec = serve(handler, http.MethodPut, "/", "application/json", `{"level":"debug"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"debug"}`, rec.Body.String())
+	assert.Equal(t, logging.DebugLevel, logger.GetLevel())
+
+	rec = serve(handler, http.MethodPut, "/", "application/x-www-form-urlencoded", "level=warn")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, logging.WarnLevel, logger.GetLevel())
+
+	rec = serve(handler, http.MethodPut, "/", "application/json", `{"level":"loud"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, logging.WarnLevel, logger.GetLevel(), "Invalid level should be ignored")
+
+	rec = serve(handler, http.MethodPost, "/", "", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Allow"))
+}
+
+func TestHandler_NamedLevels(t *testing.T) {
+	t.Cleanup(logging.NamedLevels.Reset)
+
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.InfoLevel)
+	handler := NewHandler(logger)
+
+	rec := serve(handler, http.MethodPut, "/?name=db", "application/json", `{"level":"trace"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name":"db","level":"trace"}`, rec.Body.String())
+	assert.Equal(t, logging.InfoLevel, logger.GetLevel(), "Named level should not change target level")
+
+	rec = serve(handler, http.MethodGet, "/?name=db.pool", "", "")
+	assert.JSONEq(t, `{"name":"db.pool","level":"trace"}`, rec.Body.String())
+
+	rec = serve(handler, http.MethodGet, "/", "", "")
+	assert.JSONEq(t, `{"level":"info","names":{"db":"trace"}}`, rec.Body.String())
+
+	rec = serve(handler, http.MethodDelete, "/?name=db", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name":"db","level":"info"}`, rec.Body.String())
+
+	rec = serve(handler, http.MethodDelete, "/", "", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// fixedLeveler — target, который не принимает уровни, кроме Info, как бэкенд без
+// пользовательских уровней.
+type fixedLeveler struct{}
+
+func (fixedLeveler) GetLevel() logging.Level { return logging.InfoLevel }
+
+func (fixedLeveler) SetLevel(logging.Level) {}
+
+func TestHandler_RejectedLevel(t *testing.T) {
+	t.Cleanup(logging.NamedLevels.Reset)
+	notice := logging.MustRegisterLevel("levelctl-notice", 14, logging.Cyan)
+	handler := NewHandler(fixedLeveler{})
+
+	rec := serve(handler, http.MethodPut, "/", "application/json", `{"level":"debug"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.JSONEq(t, `{"error":"level debug is not supported by the logger, level is info"}`, rec.Body.String())
+
+	rec = serve(handler, http.MethodPut, "/", "application/json", `{"level":"info"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(handler, http.MethodPut, "/?name=db", "application/json", `{"level":"`+notice.String()+`"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	_, ok := logging.NamedLevels.Lookup("db")
+	assert.False(t, ok, "Custom named level should not be stored")
+}
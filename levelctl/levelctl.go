@@ -0,0 +1,19 @@
+// Package levelctl управляет уровнем логирования во время работы процесса.
+package levelctl
+
+import "github.com/vsysa/logging"
+
+// Leveler — объект, уровнем которого управляет пакет. Ему соответствуют как
+// logging.Logger, так и factory.LevelFactory.
+type Leveler interface {
+	GetLevel() logging.Level
+	SetLevel(level logging.Level)
+}
+
+// LevelResetter — Leveler, которому можно задать собственный уровень поверх унаследованного
+// и снять его, как производному logging.Logger. Ему соответствуют логгеры пакета logger.
+type LevelResetter interface {
+	Leveler
+	HasLevelOverride() bool
+	ResetLevel()
+}
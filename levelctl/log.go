@@ -0,0 +1,56 @@
+package levelctl
+
+import (
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/factory"
+)
+
+// loggerFor возвращает логгер для сообщений о смене уровня target: сам target, если это
+// logging.Logger, или логгер, созданный фабрикой target.
+func loggerFor(target Leveler) logging.Logger {
+	switch t := target.(type) {
+	case logging.Logger:
+		return t
+	case factory.LoggerFactory:
+		return t.CreateLogger()
+	default:
+		return nil
+	}
+}
+
+// setLevelLogged меняет уровень target и пишет об этом в logger.
+//
+// Сообщение пишется на более подробном из двух уровней (но не ниже Info и не выше
+// Error) и тогда, когда этот уровень действует, чтобы смена была видна в логе в обе стороны.
+func setLevelLogged(target Leveler, logger logging.Logger, from, to logging.Level, keysAndValues ...any) {
+	changeLevelLogged(logger, from, to, func() { target.SetLevel(to) }, keysAndValues...)
+}
+
+// changeLevelLogged меняет уровень с from на to через apply и пишет об этом в logger
+// так же, как setLevelLogged.
+func changeLevelLogged(logger logging.Logger, from, to logging.Level, apply func(), keysAndValues ...any) {
+	if from == to {
+		return
+	}
+	if logger == nil {
+		apply()
+		return
+	}
+
+	messageLevel := min(max(min(from, to), logging.InfoLevel), logging.ErrorLevel)
+	keysAndValues = append(keysAndValues, "from", from.String(), "to", to.String())
+	if to < from {
+		apply()
+	}
+	switch messageLevel {
+	case logging.InfoLevel:
+		logger.Infow("Log level changed", keysAndValues...)
+	case logging.WarnLevel:
+		logger.Warnw("Log level changed", keysAndValues...)
+	default:
+		logger.Errorw("Log level changed", keysAndValues...)
+	}
+	if to > from {
+		apply()
+	}
+}
@@ -0,0 +1,30 @@
+package levelctl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/zaplog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"testing"
+)
+
+func TestSetLevelLogged(t *testing.T) {
+	atomicLevel := zap.NewAtomicLevelAt(zap.ErrorLevel)
+	core, logs := observer.New(atomicLevel)
+	logger := zaplog.NewZapLogger(zap.New(core), atomicLevel)
+
+	setLevelLogged(logger, logger, logging.ErrorLevel, logging.DebugLevel, "source", "test")
+	assert.Equal(t, logging.DebugLevel, logger.GetLevel())
+
+	setLevelLogged(logger, logger, logging.DebugLevel, logging.ErrorLevel, "source", "test")
+	assert.Equal(t, logging.ErrorLevel, logger.GetLevel())
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2, "Both changes should be visible in the log")
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, map[string]interface{}{"source": "test", "from": "error", "to": "debug"}, entries[0].ContextMap())
+	assert.Equal(t, map[string]interface{}{"source": "test", "from": "debug", "to": "error"}, entries[1].ContextMap())
+}
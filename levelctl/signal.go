@@ -0,0 +1,106 @@
+//go:build unix
+
+package levelctl
+
+import (
+	"github.com/vsysa/logging"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// steps — уровни, между которыми переключают сигналы, от самого подробного к самому строгому.
+var steps = []logging.Level{
+	logging.TraceLevel,
+	logging.DebugLevel,
+	logging.InfoLevel,
+	logging.WarnLevel,
+	logging.ErrorLevel,
+	logging.FatalLevel,
+}
+
+type SignalOptions struct {
+	// Down делает вывод подробнее на один уровень (к Trace). По умолчанию SIGUSR1.
+	Down os.Signal
+	// Up делает вывод строже на один уровень (к Fatal). По умолчанию SIGUSR2.
+	Up os.Signal
+	// Reset возвращает уровень Default. По умолчанию SIGHUP.
+	Reset os.Signal
+	// Default — уровень для Reset. По умолчанию уровень target на момент вызова WatchSignals.
+	Default logging.Level
+	// Logger пишет сообщение о смене уровня. По умолчанию сам target, если это
+	// logging.Logger, или логгер, созданный фабрикой target.
+	Logger logging.Logger
+}
+
+// WatchSignals меняет уровень target по сигналам и пишет о каждой смене в лог.
+// Возвращает функцию, которая прекращает обработку сигналов.
+func WatchSignals(target Leveler, opts SignalOptions) (stop func()) {
+	if opts.Down == nil {
+		opts.Down = syscall.SIGUSR1
+	}
+	if opts.Up == nil {
+		opts.Up = syscall.SIGUSR2
+	}
+	if opts.Reset == nil {
+		opts.Reset = syscall.SIGHUP
+	}
+	if opts.Default == 0 {
+		opts.Default = target.GetLevel()
+	}
+	if opts.Logger == nil {
+		opts.Logger = loggerFor(target)
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, opts.Down, opts.Up, opts.Reset)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				from := target.GetLevel()
+				to := from
+				switch sig {
+				case opts.Down:
+					to = step(from, -1)
+				case opts.Up:
+					to = step(from, 1)
+				case opts.Reset:
+					to = opts.Default
+				}
+				setLevelLogged(target, opts.Logger, from, to, "signal", sig.String())
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// step возвращает соседний стандартный уровень в направлении direction.
+func step(level logging.Level, direction int) logging.Level {
+	for i, s := range steps {
+		if s < level {
+			continue
+		}
+		if s > level && direction > 0 {
+			// level не из steps: ближайший более строгий уровень и есть следующий шаг
+			return s
+		}
+		next := i + direction
+		if next < 0 || next >= len(steps) {
+			return level
+		}
+		return steps[next]
+	}
+	if direction < 0 {
+		return steps[len(steps)-1]
+	}
+	return level
+}
@@ -0,0 +1,42 @@
+//go:build unix
+
+package levelctl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/testlog"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestStep(t *testing.T) {
+	assert.Equal(t, logging.DebugLevel, step(logging.InfoLevel, -1))
+	assert.Equal(t, logging.WarnLevel, step(logging.InfoLevel, 1))
+	assert.Equal(t, logging.TraceLevel, step(logging.TraceLevel, -1), "Trace is the lowest step")
+	assert.Equal(t, logging.FatalLevel, step(logging.FatalLevel, 1), "Fatal is the highest step")
+	assert.Equal(t, logging.DebugLevel, step(logging.Level(10), -1))
+	assert.Equal(t, logging.InfoLevel, step(logging.Level(10), 1))
+}
+
+func TestWatchSignals(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.InfoLevel)
+
+	stop := WatchSignals(logger, SignalOptions{})
+	defer stop()
+
+	sendAndWait := func(sig syscall.Signal, want logging.Level) {
+		require.NoError(t, syscall.Kill(syscall.Getpid(), sig))
+		require.Eventually(t, func() bool {
+			return logger.GetLevel() == want
+		}, time.Second, 5*time.Millisecond, "level after %s", sig)
+	}
+
+	sendAndWait(syscall.SIGUSR1, logging.DebugLevel)
+	sendAndWait(syscall.SIGUSR1, logging.TraceLevel)
+	sendAndWait(syscall.SIGUSR2, logging.DebugLevel)
+	sendAndWait(syscall.SIGHUP, logging.InfoLevel)
+}
@@ -20,7 +20,7 @@ func CtxWithLogger(ctx context.Context, l logging.Logger) context.Context {
 
 func LoggerFromCtx(ctx context.Context) logging.Logger {
 	if l, ok := ctx.Value(ctxLogger{}).(logging.Logger); ok {
-		return l
+		return withCtxLevel(ctx, l)
 	}
 
 	if loggerFactory == nil {
@@ -30,9 +30,36 @@ func LoggerFromCtx(ctx context.Context) logging.Logger {
 
 	logger := loggerFactory.CreateLogger()
 	logger.AddContext("type", "defaultLoggerFromCtx")
-	return logger
+	return withCtxLevel(ctx, logger)
 }
 
 func L(ctx context.Context) logging.Logger {
 	return LoggerFromCtx(ctx)
 }
+
+// With сохраняет в контекст производный логгер с добавленными полями.
+// Логгер, уже лежащий в ctx, не изменяется, поэтому его могут безопасно использовать другие горутины.
+func With(ctx context.Context, keysAndValues ...any) context.Context {
+	return CtxWithLogger(ctx, LoggerFromCtx(ctx).With(keysAndValues...))
+}
+
+// WithLevel сохраняет в контекст уровень запроса: логгеры из L(ctx) и логгеры, привязанные
+// через SetCtx(ctx), пишут на уровне level только в рамках этого запроса. Тот же уровень
+// можно передать из другого сервиса элементом baggage logging.BaggageLevelKey, если включен
+// logging.SetBaggageLevelEnabled.
+func WithLevel(ctx context.Context, level logging.Level) context.Context {
+	ctx = logging.ContextWithLevel(ctx, level)
+	return CtxWithLogger(ctx, LoggerFromCtx(ctx))
+}
+
+// withCtxLevel возвращает l или, если уровень запроса в ctx отличается от уровня l,
+// его клон с этим уровнем. Сам l не изменяется.
+func withCtxLevel(ctx context.Context, l logging.Logger) logging.Logger {
+	level, ok := logging.LevelFromContext(ctx)
+	if !ok || level == l.GetLevel() {
+		return l
+	}
+	clone := l.Clone()
+	clone.SetLevel(level)
+	return clone
+}
@@ -0,0 +1,51 @@
+package logctx
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/testlog"
+	"go.opentelemetry.io/otel/baggage"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	ctx := CtxWithLogger(context.Background(), logger)
+
+	requestCtx := With(ctx, "request_id", "xyz789")
+
+	assert.Equal(t, map[string]interface{}{"request_id": "xyz789"}, L(requestCtx).GetAllContexts())
+	assert.Empty(t, L(ctx).GetAllContexts(), "Logger in parent context should remain unchanged")
+}
+
+func TestWithLevel(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.InfoLevel)
+	ctx := CtxWithLogger(context.Background(), logger)
+
+	requestCtx := WithLevel(ctx, logging.TraceLevel)
+
+	assert.Equal(t, logging.TraceLevel, L(requestCtx).GetLevel())
+	assert.Equal(t, logging.TraceLevel, testlog.NewTestLogger(nil).SetCtx(requestCtx).GetLevel())
+	assert.Equal(t, logging.InfoLevel, L(ctx).GetLevel(), "Logger outside the request should keep its level")
+	assert.Equal(t, logging.InfoLevel, logger.GetLevel())
+}
+
+func TestL_BaggageLevel(t *testing.T) {
+	logger := testlog.NewTestLogger(nil)
+	logger.SetLevel(logging.InfoLevel)
+
+	member, err := baggage.NewMember(logging.BaggageLevelKey, "debug")
+	require.NoError(t, err)
+	bag, err := baggage.New(member)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(CtxWithLogger(context.Background(), logger), bag)
+
+	logging.SetBaggageLevelEnabled(true)
+	defer logging.SetBaggageLevelEnabled(false)
+
+	assert.Equal(t, logging.DebugLevel, L(ctx).GetLevel())
+	assert.Equal(t, logging.InfoLevel, logger.GetLevel())
+}
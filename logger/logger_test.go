@@ -1,13 +1,22 @@
 package logger
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/vsysa/logging"
 	"github.com/vsysa/logging/logger/logruslog"
+	"github.com/vsysa/logging/logger/sloglog"
 	"github.com/vsysa/logging/logger/zaplog"
+	"github.com/vsysa/logging/logger/zerologlog"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"log/slog"
 	"os"
+	"runtime"
 	"testing"
 )
 
@@ -16,7 +25,7 @@ func getLoggerForTest() []struct {
 	logger logging.Logger
 } {
 	encoderConfig := zap.NewDevelopmentEncoderConfig()
-	encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // CapitalColorLevelEncoder добавляет цвет в зависимости от уровня
+	encoderConfig.EncodeLevel = zaplog.CapitalColorLevelEncoder // CapitalColorLevelEncoder добавляет цвет в зависимости от уровня
 	atomicLevel := zap.NewAtomicLevelAt(zap.DebugLevel)
 
 	consoleCore := zapcore.NewCore(
@@ -30,7 +39,83 @@ func getLoggerForTest() []struct {
 		logger logging.Logger
 	}{
 		{"LogrusLogger", logruslog.NewLogrusLogger()},
-		{"ZapLogger", zaplog.NewZapLogger(zap.New(consoleCore, zap.AddCaller(), zap.AddCallerSkip(2)), atomicLevel)},
+		{"ZapLogger", zaplog.NewZapLogger(zap.New(consoleCore), atomicLevel)},
+		{"SlogLogger", sloglog.NewSlogLogger(nil, nil)},
+		{"ZerologLogger", zerologlog.NewZerologLogger(nil, nil)},
+	}
+}
+
+// getBufferedLoggersForTest возвращает бэкенды, пишущие в buf с местом вызова.
+func getBufferedLoggersForTest(buf *bytes.Buffer) []struct {
+	name   string
+	logger logging.Logger
+} {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.FunctionKey = "func"
+	zapLogger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(buf), zap.DebugLevel))
+	zerologLogger := zerolog.New(buf)
+	// slog выводит source объектом, здесь он сводится к "функция файл:строка"
+	slogOptions := &slog.HandlerOptions{AddSource: true, ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if source, ok := a.Value.Any().(*slog.Source); ok {
+			a.Value = slog.StringValue(fmt.Sprintf("%s %s:%d", source.Function, source.File, source.Line))
+		}
+		return a
+	}}
+
+	return []struct {
+		name   string
+		logger logging.Logger
+	}{
+		{"LogrusLogger", logruslog.NewLogrusLoggerWithRoutes(logging.Routes{{Level: logging.TraceLevel, Writer: buf}})},
+		{"ZapLogger", zaplog.NewZapLogger(zapLogger, zap.AtomicLevel{})},
+		{"SlogLogger", sloglog.NewSlogLogger(slog.NewJSONHandler(buf, slogOptions), nil)},
+		{"ZerologLogger", zerologlog.NewZerologLogger(&zerologLogger, nil)},
+	}
+}
+
+// nextLine возвращает номер строки, следующей за вызовом nextLine.
+func nextLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line + 1
+}
+
+// logVia — вспомогательная функция логирования, которая не должна попадать в caller.
+func logVia(logger logging.Logger, message string) {
+	logger.WithCallerSkip(1).Warnw(message)
+}
+
+func TestBaseLogger_Caller(t *testing.T) {
+	buf := &bytes.Buffer{}
+	for _, tt := range getBufferedLoggersForTest(buf) {
+		t.Run(tt.name, func(t *testing.T) {
+			assertCaller := func(line int) {
+				t.Helper()
+				assert.Contains(t, buf.String(), fmt.Sprintf("logger_test.go:%d", line))
+				assert.Contains(t, buf.String(), "logger.TestBaseLogger_Caller")
+				assert.NotContains(t, buf.String(), "logger.logVia")
+				buf.Reset()
+			}
+
+			line := nextLine()
+			tt.logger.Info("direct")
+			assertCaller(line)
+
+			line = nextLine()
+			tt.logger.Clone().With("key", "value").Named("child").Errorw("derived")
+			assertCaller(line)
+
+			line = nextLine()
+			tt.logger.ErrorCatch(errors.New("boom"), "catch")
+			assertCaller(line)
+
+			line = nextLine()
+			tt.logger.Log(logging.WarnLevel, "via Log")
+			assertCaller(line)
+
+			line = nextLine()
+			logVia(tt.logger, "helper")
+			assertCaller(line)
+		})
 	}
 }
 
@@ -48,7 +133,7 @@ func TestBaseLogger_AddAndDeleteContext(t *testing.T) {
 			logger.AddContexts(map[string]interface{}{"key3": 3.0, "key4": true})
 
 			// Verify all contexts are added
-			expectedContexts := map[string]interface{}{"key1": "value1", "key2": "2", "key3": "3", "key4": "true"}
+			expectedContexts := map[string]interface{}{"key1": "value1", "key2": 2, "key3": 3.0, "key4": true}
 			assert.Equal(t, expectedContexts, logger.GetAllContexts(), "Contexts should match expected")
 
 			// Test DeleteContext
@@ -78,3 +163,195 @@ func TestBaseLogger_Clone(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseLogger_With(t *testing.T) {
+	tests := getLoggerForTest()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := tt.logger
+			parent.AddContext("key", "value")
+
+			child := parent.With("request_id", "xyz789", "attempt", 2)
+
+			assert.Equal(t, map[string]interface{}{"key": "value", "request_id": "xyz789", "attempt": 2}, child.GetAllContexts(), "Child should extend parent context")
+			assert.Equal(t, map[string]interface{}{"key": "value"}, parent.GetAllContexts(), "Parent context should remain unchanged")
+
+			parent.AddContext("late", true)
+			assert.NotContains(t, child.GetAllContexts(), "late", "Child should own its field set")
+		})
+	}
+}
+
+func TestBaseLogger_FatalExitFunc(t *testing.T) {
+	tests := getLoggerForTest()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var codes []int
+			tt.logger.(logging.ExitFuncSetter).SetExitFunc(func(code int) {
+				codes = append(codes, code)
+			})
+
+			tt.logger.Fatal("fatal")
+			tt.logger.FatalCatch(errors.New("boom"), "fatal catch")
+			tt.logger.With("key", "value").Fatalw("fatal kv")
+			tt.logger.Log(logging.FatalLevel, "fatal via Log")
+			tt.logger.Logw(logging.FatalLevel, "fatal via Logw")
+
+			assert.Equal(t, []int{1, 1, 1, 1, 1}, codes, "Every Fatal path should call ExitFunc with code 1")
+		})
+	}
+}
+
+func TestBaseLogger_Named(t *testing.T) {
+	tests := getLoggerForTest()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := tt.logger.Named("db").Named("pool")
+			assert.Equal(t, "db.pool", pool.GetAllContexts()[logging.LoggerNameKey])
+
+			conn := pool.With("conn", 1).Named("conn")
+			assert.Equal(t, "db.pool.conn", conn.GetAllContexts()[logging.LoggerNameKey])
+			assert.NotContains(t, tt.logger.GetAllContexts(), logging.LoggerNameKey, "Parent should stay unnamed")
+		})
+	}
+}
+
+func TestBaseLogger_GetLevelAndEnabled(t *testing.T) {
+	tests := getLoggerForTest()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := tt.logger
+			logger.SetLevel(logging.WarnLevel)
+
+			assert.Equal(t, logging.WarnLevel, logger.GetLevel())
+			assert.False(t, logger.Enabled(logging.InfoLevel))
+			assert.True(t, logger.Enabled(logging.WarnLevel))
+			assert.True(t, logger.Enabled(logging.ErrorLevel))
+
+			child := logger.Clone()
+			child.SetLevel(logging.TraceLevel)
+			assert.Equal(t, logging.TraceLevel, child.GetLevel())
+			assert.True(t, child.Enabled(logging.TraceLevel))
+			assert.Equal(t, logging.WarnLevel, logger.GetLevel(), "Child override should not change parent level")
+		})
+	}
+}
+
+func TestBaseLogger_SetCtxLevel(t *testing.T) {
+	tests := getLoggerForTest()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := tt.logger
+			logger.SetLevel(logging.WarnLevel)
+
+			requestLogger := logger.SetCtx(logging.ContextWithLevel(context.Background(), logging.TraceLevel))
+			assert.NotSame(t, logger, requestLogger, "SetCtx should return a derived logger")
+			assert.Equal(t, logging.TraceLevel, requestLogger.GetLevel())
+			assert.True(t, requestLogger.Enabled(logging.TraceLevel))
+			assert.Equal(t, logging.WarnLevel, logger.GetLevel(), "Request level should not change the parent level")
+
+			plainLogger := logger.SetCtx(context.Background())
+			assert.Equal(t, logging.WarnLevel, plainLogger.GetLevel())
+
+			logger.SetLevel(logging.ErrorLevel)
+			assert.Equal(t, logging.ErrorLevel, logger.GetLevel(), "Root level should stay under SetLevel control")
+			assert.Equal(t, logging.ErrorLevel, plainLogger.GetLevel(), "Logger without request level should follow the parent")
+			assert.Equal(t, logging.TraceLevel, requestLogger.GetLevel())
+		})
+	}
+}
+
+func TestBaseLogger_Panic(t *testing.T) {
+	tests := getLoggerForTest()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.New("boom")
+
+			assert.PanicsWithValue(t, "panic 1", func() { tt.logger.Panic("panic %d", 1) })
+			assert.PanicsWithError(t, "boom", func() { tt.logger.PanicCatch(err, "panic catch") })
+			assert.PanicsWithValue(t, "panic catch", func() { tt.logger.PanicCatch(nil, "panic catch") })
+			assert.NotPanics(t, func() { tt.logger.Log(logging.PanicLevel, "logged only") })
+			assert.True(t, tt.logger.Enabled(logging.PanicLevel))
+		})
+	}
+}
+
+func TestBaseLogger_SetLevelPanic(t *testing.T) {
+	buf := &bytes.Buffer{}
+	for _, tt := range getBufferedLoggersForTest(buf) {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			logger := tt.logger
+			logger.SetLevel(logging.PanicLevel)
+
+			assert.Equal(t, logging.PanicLevel, logger.GetLevel())
+			assert.False(t, logger.Enabled(logging.ErrorLevel))
+			assert.True(t, logger.Enabled(logging.PanicLevel))
+			assert.True(t, logger.Enabled(logging.FatalLevel))
+
+			logger.Error("dropped")
+			logger.Log(logging.PanicLevel, "kept")
+			assert.NotContains(t, buf.String(), "dropped")
+			assert.Contains(t, buf.String(), "kept")
+
+			child := logger.Clone()
+			child.SetLevel(logging.InfoLevel)
+			child.SetLevel(logging.PanicLevel)
+			assert.Equal(t, logging.PanicLevel, child.GetLevel())
+
+			logger.SetLevel(logging.ErrorLevel)
+			assert.Equal(t, logging.ErrorLevel, logger.GetLevel())
+			assert.True(t, logger.Enabled(logging.ErrorLevel))
+		})
+	}
+}
+
+// countingError считает обращения к LogFields.
+type countingError struct {
+	calls int
+}
+
+func (e *countingError) Error() string { return "counted" }
+
+func (e *countingError) LogFields() map[string]any {
+	e.calls++
+	return map[string]any{"calls": e.calls}
+}
+
+func TestBaseLogger_CatchDisabledLevel(t *testing.T) {
+	tests := getLoggerForTest()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.logger.(logging.ExitFuncSetter).SetExitFunc(func(int) {})
+			logger := tt.logger.Clone()
+			logger.SetLevel(logging.FatalLevel)
+			err := &countingError{}
+
+			logger.ErrorCatch(err, "disabled")
+			assert.Panics(t, func() { logger.PanicCatch(err, "disabled") })
+			assert.Zero(t, err.calls, "Error fields should not be collected for a disabled level")
+
+			logger.FatalCatch(err, "enabled")
+			assert.Equal(t, 1, err.calls)
+		})
+	}
+}
+
+func TestBaseLogger_Sync(t *testing.T) {
+	tests := getLoggerForTest()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.logger.Info("before sync")
+			assert.NoError(t, tt.logger.Sync(), "Syncing stdout and stderr should not fail")
+			assert.NoError(t, tt.logger.With("key", "value").Sync())
+		})
+	}
+}
@@ -2,32 +2,119 @@ package logruslog
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"github.com/sirupsen/logrus"
 	"github.com/vsysa/logging"
 	"github.com/vsysa/logging/internal/helper"
-	"go.opentelemetry.io/otel/trace"
+	"io"
 	"os"
+	"reflect"
 	"sync"
 )
 
+// LevelNameKey — поле с именем пользовательского уровня. В logrus нельзя добавить свой
+// уровень, поэтому такая запись пишется на ближайшем менее строгом уровне logrus.
+// Так же пишется logging.PanicLevel: logrus.PanicLevel строже Fatal, а Entry.Log на нем паникует.
+const LevelNameKey = "level_name"
+
+// levelMap переводит уровни logging в уровни logrus. logging.PanicLevel переводится
+// в logrus.ErrorLevel, поэтому LogrusLogger хранит уровень в единицах logging.
 var levelMap = map[logging.Level]logrus.Level{
 	logging.TraceLevel: logrus.TraceLevel,
 	logging.DebugLevel: logrus.DebugLevel,
 	logging.InfoLevel:  logrus.InfoLevel,
 	logging.WarnLevel:  logrus.WarnLevel,
 	logging.ErrorLevel: logrus.ErrorLevel,
+	logging.PanicLevel: logrus.ErrorLevel,
 	logging.FatalLevel: logrus.FatalLevel,
 }
 
+// levels — уровни, которые можно задать LogrusLogger.
+var levels = helper.LevelKeys(levelMap)
+
 type LogrusLogger struct {
 	logrus    *logrus.Logger
+	bypass    *bypassLogger
 	contextMu sync.RWMutex
 	context   map[string]interface{}
+	name      string
 	timerMu   sync.RWMutex
+	rootLevel *helper.AliasedLevel[logrus.Level]
+	level     *helper.LevelNode[logging.Level]
+	exitFunc  logging.ExitFunc
+	// callerSkip — дополнительные кадры над публичным методом (см. WithCallerSkip),
+	// caller — заданное через WithCaller место вызова.
+	callerSkip int
+	caller     uintptr
+}
+
+// bypassLogger — копия настроек logrus.Logger на уровне Trace. Через нее пишут клоны,
+// чей собственный уровень ниже уровня общего logrus.Logger. Создается при первом
+// использовании, поэтому последующие изменения Out или Formatter в нее не попадают.
+type bypassLogger struct {
+	once   sync.Once
+	logger *logrus.Logger
+}
+
+func (b *bypassLogger) get(l *logrus.Logger) *logrus.Logger {
+	b.once.Do(func() {
+		b.logger = &logrus.Logger{
+			Out:          l.Out,
+			Hooks:        l.Hooks,
+			Formatter:    l.Formatter,
+			ReportCaller: l.ReportCaller,
+			Level:        logrus.TraceLevel,
+			ExitFunc:     l.ExitFunc,
+		}
+	})
+	return b.logger
+}
+
+// entryKey — ключ контекста записи, в котором LogrusLogger.log передает хукам entryInfo.
+type entryKey struct{}
+
+// entryInfo — данные записи, которые не должны зависеть от ее полей: место вызова и
+// уровень logging (по нему RoutingHook выбирает приемник).
+type entryInfo struct {
+	caller uintptr
+	level  logging.Level
+}
+
+// entryInfoOf возвращает entryInfo записи, если она создана LogrusLogger.
+func entryInfoOf(entry *logrus.Entry) (entryInfo, bool) {
+	if entry.Context == nil {
+		return entryInfo{}, false
+	}
+	info, ok := entry.Context.Value(entryKey{}).(entryInfo)
+	return info, ok
+}
+
+// callerHook подставляет в запись место вызова, найденное LogrusLogger. Сам logrus
+// с ReportCaller считает вызывающим первый кадр вне своего пакета, то есть LogrusLogger.
+// Должен быть добавлен раньше хуков, которые форматируют запись.
+type callerHook struct{}
+
+func (callerHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (callerHook) Fire(entry *logrus.Entry) error {
+	if info, ok := entryInfoOf(entry); ok && info.caller != 0 {
+		frame := helper.CallerFrame(info.caller)
+		entry.Caller = &frame
+	}
+	return nil
 }
 
+// NewLogrusLogger создает LogrusLogger с маршрутами logging.DefaultRoutes: Warn и выше
+// пишутся в stderr, остальное — в stdout.
 func NewLogrusLogger() *LogrusLogger {
+	return NewLogrusLoggerWithRoutes(logging.DefaultRoutes())
+}
+
+// NewLogrusLoggerWithRoutes создает LogrusLogger, который пишет записи в приемники routes
+// по их уровню (см. RoutingHook).
+func NewLogrusLoggerWithRoutes(routes logging.Routes) *LogrusLogger {
 	l := logrus.New()
 	l.SetFormatter(&logrus.TextFormatter{
 		TimestampFormat: "2006-01-02T15:04:05.999",
@@ -35,14 +122,19 @@ func NewLogrusLogger() *LogrusLogger {
 		FullTimestamp:   true,
 	})
 
-	l.SetOutput(&levelAwareWriter{
-		InfoWriter:  os.Stdout,
-		ErrorWriter: os.Stderr,
-	})
+	l.SetReportCaller(true)
+	l.SetOutput(io.Discard)
+	l.AddHook(callerHook{})
+	l.AddHook(NewRoutingHook(routes))
 
+	rootLevel := helper.NewAliasedLevel(levelMap, false, l.GetLevel, l.SetLevel)
 	newLogger := &LogrusLogger{
-		logrus:  l,
-		context: make(map[string]interface{}),
+		logrus:    l,
+		bypass:    &bypassLogger{},
+		context:   make(map[string]interface{}),
+		rootLevel: rootLevel,
+		level:     helper.NewLevelNode(rootLevel.Level),
+		exitFunc:  os.Exit,
 	}
 
 	return newLogger
@@ -60,7 +152,7 @@ func NewLogrusLoggerWithParams(params map[string]interface{}) *LogrusLogger {
 func (r *LogrusLogger) AddContext(key string, value interface{}) logging.Logger {
 	r.contextMu.Lock()
 	defer r.contextMu.Unlock()
-	r.context[key] = fmt.Sprintf("%v", value)
+	r.context[key] = helper.NormalizeValue(value)
 	return r
 }
 
@@ -69,7 +161,7 @@ func (r *LogrusLogger) AddContexts(contexts map[string]interface{}) logging.Logg
 	r.contextMu.Lock()
 	defer r.contextMu.Unlock()
 	for key, value := range contexts {
-		r.context[key] = fmt.Sprintf("%v", value)
+		r.context[key] = helper.NormalizeValue(value)
 	}
 	return r
 }
@@ -95,90 +187,279 @@ func (r *LogrusLogger) GetAllContexts() map[string]interface{} {
 
 //	LOGGING
 
+// SetLevel меняет уровень общего logrus.Logger для корневого логгера и собственный уровень для клона.
 func (r *LogrusLogger) SetLevel(level logging.Level) {
-	if logrusLevel, ok := levelMap[level]; ok {
-		r.logrus.SetLevel(logrusLevel)
-	} else {
+	if _, ok := levelMap[level]; !ok {
 		r.Warn("Invalid logging level: %v", level)
 		return
+	} else if r.level.IsRoot() {
+		r.rootLevel.SetLevel(level)
+	} else {
+		r.level.SetOverride(level)
 	}
 }
 
+func (r *LogrusLogger) ResetLevel() {
+	r.level.ClearOverride()
+}
+
+// HasLevelOverride сообщает, задан ли логгеру собственный уровень (см. ResetLevel).
+func (r *LogrusLogger) HasLevelOverride() bool {
+	return r.level.HasOverride()
+}
+
+// GetLevel возвращает действующий уровень логгера с учетом наследования.
+func (r *LogrusLogger) GetLevel() logging.Level {
+	return r.level.Level()
+}
+
+func (r *LogrusLogger) Enabled(level logging.Level) bool {
+	_, ok := helper.BackendLevel(levelMap, level)
+	return ok && level >= r.level.Level()
+}
+
 func (r *LogrusLogger) Trace(message string, a ...any) {
-	r.log(logrus.TraceLevel, fmt.Sprintf(message, a...))
+	r.log(logging.TraceLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *LogrusLogger) Debug(message string, a ...any) {
-	r.log(logrus.DebugLevel, fmt.Sprintf(message, a...))
+	r.log(logging.DebugLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *LogrusLogger) Info(message string, a ...any) {
-	r.log(logrus.InfoLevel, fmt.Sprintf(message, a...))
+	r.log(logging.InfoLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *LogrusLogger) Warn(message string, a ...any) {
-	r.log(logrus.WarnLevel, fmt.Sprintf(message, a...))
+	r.log(logging.WarnLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *LogrusLogger) Error(message string, a ...any) {
-	r.log(logrus.ErrorLevel, fmt.Sprintf(message, a...))
+	r.log(logging.ErrorLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *LogrusLogger) Fatal(message string, a ...any) {
-	r.log(logrus.FatalLevel, fmt.Sprintf(message, a...))
+	r.log(logging.FatalLevel, helper.FormatMessage(message, a), nil)
+	r.exit()
 }
 
 func (r *LogrusLogger) ErrorCatch(err error, message string, a ...any) {
-	msg := fmt.Sprintf(message, a...)
-	if err != nil {
-		msg += ": " + err.Error()
+	if r.Enabled(logging.ErrorLevel) {
+		r.log(logging.ErrorLevel, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.ErrorLevel, r.callerSkip))
 	}
-	r.log(logrus.ErrorLevel, msg)
 }
 
 func (r *LogrusLogger) FatalCatch(err error, message string, a ...any) {
-	msg := fmt.Sprintf(message, a...)
+	if r.Enabled(logging.FatalLevel) {
+		r.log(logging.FatalLevel, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.FatalLevel, r.callerSkip))
+	}
+	r.exit()
+}
+
+func (r *LogrusLogger) Panic(message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	r.log(logging.PanicLevel, msg, nil)
+	panic(msg)
+}
+
+func (r *LogrusLogger) PanicCatch(err error, message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	if r.Enabled(logging.PanicLevel) {
+		r.log(logging.PanicLevel, msg, helper.ErrorFields(err, logging.PanicLevel, r.callerSkip))
+	}
 	if err != nil {
-		msg += ": " + err.Error()
+		panic(err)
+	}
+	panic(msg)
+}
+
+func (r *LogrusLogger) Tracew(message string, keysAndValues ...any) {
+	r.log(logging.TraceLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *LogrusLogger) Debugw(message string, keysAndValues ...any) {
+	r.log(logging.DebugLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *LogrusLogger) Infow(message string, keysAndValues ...any) {
+	r.log(logging.InfoLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *LogrusLogger) Warnw(message string, keysAndValues ...any) {
+	r.log(logging.WarnLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *LogrusLogger) Errorw(message string, keysAndValues ...any) {
+	r.log(logging.ErrorLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *LogrusLogger) Fatalw(message string, keysAndValues ...any) {
+	r.log(logging.FatalLevel, message, helper.KeysAndValues(keysAndValues))
+	r.exit()
+}
+
+// Log пишет запись на уровне level. Пользовательский уровень пишется на ближайшем
+// менее строгом уровне logrus с полем LevelNameKey.
+func (r *LogrusLogger) Log(level logging.Level, message string, a ...any) {
+	if _, ok := helper.BackendLevel(levelMap, level); !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(level, helper.FormatMessage(message, a), nil)
+	if level == logging.FatalLevel {
+		r.exit()
+	}
+}
+
+func (r *LogrusLogger) Logw(level logging.Level, message string, keysAndValues ...any) {
+	if _, ok := helper.BackendLevel(levelMap, level); !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(level, message, helper.KeysAndValues(keysAndValues))
+	if level == logging.FatalLevel {
+		r.exit()
+	}
+}
+
+// customLevelField добавляет в fields имя уровня, у которого нет собственного уровня logrus.
+func customLevelField(level logging.Level, fields map[string]interface{}) map[string]interface{} {
+	if helper.NativeLevel(levelMap, level, false) {
+		return fields
 	}
-	r.log(logrus.FatalLevel, msg)
-	os.Exit(1)
+	return helper.MergeContext(fields, map[string]interface{}{LevelNameKey: level.String()})
 }
 
 // BASE
 
 func (r *LogrusLogger) Clone() logging.Logger {
+	return r.derive(r.GetAllContexts())
+}
+
+func (r *LogrusLogger) With(keysAndValues ...any) logging.Logger {
+	return r.derive(helper.MergeContext(r.GetAllContexts(), helper.KeysAndValues(keysAndValues)))
+}
+
+func (r *LogrusLogger) Named(name string) logging.Logger {
+	fullName := logging.JoinName(r.name, name)
+	child := r.derive(helper.MergeContext(r.GetAllContexts(), map[string]interface{}{logging.LoggerNameKey: fullName}))
+	child.name = fullName
+	child.level = r.level.ChildWithLookup(helper.NamedLevelLookup(fullName, levels))
+	return child
+}
+
+// derive создает дочерний логгер с общим logrus.Logger, наследующий уровень r.
+func (r *LogrusLogger) derive(context map[string]interface{}) *LogrusLogger {
 	return &LogrusLogger{
-		logrus:  r.logrus,
-		context: helper.CopyMapContext(r.context),
+		logrus:     r.logrus,
+		bypass:     r.bypass,
+		context:    context,
+		rootLevel:  r.rootLevel,
+		name:       r.name,
+		level:      r.level.Child(),
+		exitFunc:   r.exitFunc,
+		callerSkip: r.callerSkip,
+		caller:     r.caller,
 	}
 }
 
+func (r *LogrusLogger) WithCallerSkip(skip int) logging.Logger {
+	child := r.derive(r.GetAllContexts())
+	child.callerSkip += skip
+	return child
+}
+
+func (r *LogrusLogger) WithCaller(pc uintptr) logging.Logger {
+	child := r.derive(r.GetAllContexts())
+	child.caller = pc
+	return child
+}
+
+// SetCtx возвращает производный логгер, привязанный к ctx: с traceID и spanID активного
+// спана и уровнем запроса, если он есть в ctx (см. logging.LevelFromContext). r не изменяется.
 func (r *LogrusLogger) SetCtx(ctx context.Context) logging.Logger {
-	span := trace.SpanFromContext(ctx)
-	if span.SpanContext().IsValid() {
-		traceID := span.SpanContext().TraceID().String()
-		spanID := span.SpanContext().SpanID().String()
+	child := r.derive(helper.MergeContext(r.GetAllContexts(), helper.SpanFields(ctx)))
+	if level, ok := helper.ContextLevel(ctx, levels); ok {
+		child.level.SetOverride(level)
+	}
+	return child
+}
+
+func (r *LogrusLogger) SetExitFunc(exitFunc logging.ExitFunc) {
+	r.exitFunc = exitFunc
+}
 
-		r.AddContexts(map[string]interface{}{
-			"traceID": traceID,
-			"spanID":  spanID,
-		})
+// Sync сбрасывает вывод logrus и хуков, если они это поддерживают, например RoutingHook.
+// Хук, зарегистрированный на нескольких уровнях, сбрасывается один раз.
+func (r *LogrusLogger) Sync() error {
+	var errs []error
+	if syncer, ok := r.logrus.Out.(interface{ Sync() error }); ok {
+		errs = append(errs, syncer.Sync())
 	}
-	return r
+	var synced []logrus.Hook
+	for _, level := range logrus.AllLevels {
+		for _, hook := range r.logrus.Hooks[level] {
+			syncer, ok := hook.(interface{ Sync() error })
+			if !ok || containsHook(synced, hook) {
+				continue
+			}
+			synced = append(synced, hook)
+			errs = append(errs, syncer.Sync())
+		}
+	}
+	return helper.SyncError(errors.Join(errs...))
 }
 
-func (r *LogrusLogger) log(level logrus.Level, message string) {
-	entry := r.logrus.WithFields(r.getLogrusFields()) // Использование преобразованных fields
-	entry.Log(level, message)
+// containsHook сообщает, есть ли hook в hooks. Несравнимые хуки считаются разными.
+func containsHook(hooks []logrus.Hook, hook logrus.Hook) bool {
+	if !reflect.TypeOf(hook).Comparable() {
+		return false
+	}
+	for _, h := range hooks {
+		if reflect.TypeOf(h) == reflect.TypeOf(hook) && h == hook {
+			return true
+		}
+	}
+	return false
+}
+
+// exit сбрасывает вывод через Sync и вызывает ExitFunc. Entry.Log на FatalLevel сам
+// процесс не завершает.
+func (r *LogrusLogger) exit() {
+	_ = r.Sync()
+	r.exitFunc(1)
+}
+
+// log пишет запись на уровне logrus, соответствующем level (см. helper.BackendLevel).
+func (r *LogrusLogger) log(level logging.Level, message string, fields map[string]interface{}) {
+	if level < r.level.Level() {
+		return
+	}
+	logrusLevel, _ := helper.BackendLevel(levelMap, level)
+	logger := r.logrus
+	if !logger.IsLevelEnabled(logrusLevel) {
+		logger = r.bypass.get(r.logrus)
+	}
+	ctx := context.WithValue(context.Background(), entryKey{}, entryInfo{caller: r.callerPC(), level: level})
+	entry := logger.WithFields(r.getLogrusFields(customLevelField(level, fields))).WithContext(ctx) // Использование преобразованных fields
+	entry.Log(logrusLevel, message)
+}
+
+// callerPC возвращает место вызова публичного метода логгера. Вызывается из log.
+func (r *LogrusLogger) callerPC() uintptr {
+	if r.caller != 0 {
+		return r.caller
+	}
+	return helper.CallerPC(3 + r.callerSkip)
 }
 
-func (r *LogrusLogger) getLogrusFields() logrus.Fields {
+func (r *LogrusLogger) getLogrusFields(extra map[string]interface{}) logrus.Fields {
 	fields := logrus.Fields{}
-	for key, value := range r.GetAllContexts() { // Преобразование params в logrus.Fields
+	for key, value := range helper.MergeContext(r.GetAllContexts(), extra) { // Преобразование params в logrus.Fields
 		fields[key] = value
 	}
 	return fields
 }
 
 var _ logging.Logger = &LogrusLogger{}
+var _ logging.CallerSetter = &LogrusLogger{}
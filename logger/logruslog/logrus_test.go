@@ -1,8 +1,11 @@
 package logruslog
 
 import (
+	"bytes"
+	"encoding/json"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vsysa/logging"
 	"testing"
 )
@@ -22,3 +25,48 @@ func TestBaseLogger_SetLevel(t *testing.T) {
 //	logger.Warn("This is warn log")
 //	logger.Error("This is error log")
 //}
+
+func TestLogrusLogger_CloneLevelHierarchy(t *testing.T) {
+	buf := &bytes.Buffer{}
+	parent := NewLogrusLoggerWithRoutes(logging.Routes{{Level: logging.TraceLevel, Writer: buf}})
+	parent.SetLevel(logging.InfoLevel)
+
+	child := parent.Clone()
+	sibling := parent.Clone()
+
+	child.SetLevel(logging.DebugLevel)
+	assert.Equal(t, logrus.InfoLevel, parent.logrus.GetLevel(), "Child override should not change shared logrus level")
+
+	parent.Debug("parent debug")
+	sibling.Debug("sibling debug")
+	child.Debug("child debug")
+	assert.NotContains(t, buf.String(), "parent debug")
+	assert.NotContains(t, buf.String(), "sibling debug")
+	assert.Contains(t, buf.String(), "child debug", "Only the overriding child should emit Debug")
+
+	buf.Reset()
+	child.ResetLevel()
+	child.Debug("child debug after reset")
+	assert.Empty(t, buf.String(), "Child should inherit parent level after reset")
+}
+
+func TestLogrusLogger_CustomLevel(t *testing.T) {
+	notice := logging.MustRegisterLevel("notice", 14, logging.Green)
+	buf := &bytes.Buffer{}
+	logger := NewLogrusLoggerWithRoutes(logging.Routes{{Level: logging.TraceLevel, Writer: buf}})
+	logger.logrus.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetLevel(logging.InfoLevel)
+
+	logger.Logw(notice, "custom", "key", "value")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "info", entry["level"], "Custom level should be written as the nearest less severe logrus level")
+	assert.Equal(t, "notice", entry[LevelNameKey])
+	assert.Equal(t, "value", entry["key"])
+
+	buf.Reset()
+	logger.SetLevel(logging.WarnLevel)
+	logger.Log(notice, "hidden")
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,64 @@
+package logruslog
+
+import (
+	"errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/internal/helper"
+	"slices"
+	"sync"
+)
+
+// RoutingHook — logrus.Hook, который форматирует запись Formatter'ом логгера и пишет ее
+// в приемник маршрута, выбранного по уровню записи (см. logging.Routes). Решение принимается
+// по уровню самой записи, поэтому не зависит от формата вывода и текста сообщения.
+// Out логгера при этом должен быть io.Discard, иначе запись будет выведена дважды.
+type RoutingHook struct {
+	mu     sync.Mutex
+	routes logging.Routes
+}
+
+func NewRoutingHook(routes logging.Routes) *RoutingHook {
+	return &RoutingHook{routes: slices.Clone(routes)}
+}
+
+func (h *RoutingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *RoutingHook) Fire(entry *logrus.Entry) error {
+	writer := h.routes.Writer(entryLevel(entry))
+	if writer == nil {
+		return nil
+	}
+	serialized, err := entry.Logger.Formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// Sync сбрасывает приемники, которые это поддерживают, например *os.File.
+func (h *RoutingHook) Sync() error {
+	var errs []error
+	for _, route := range h.routes {
+		if syncer, ok := route.Writer.(interface{ Sync() error }); ok {
+			errs = append(errs, syncer.Sync())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// entryLevel возвращает уровень logging записи. Уровень записи LogrusLogger берется из
+// ее контекста (см. entryInfo), а не из поля LevelNameKey, которое можно задать в полях
+// записи. Для остальных записей уровень logrus переводится в уровень logging.
+func entryLevel(entry *logrus.Entry) logging.Level {
+	if info, ok := entryInfoOf(entry); ok {
+		return info.level
+	}
+	return helper.LevelFromBackend(levelMap, entry.Level, false)
+}
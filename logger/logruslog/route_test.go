@@ -0,0 +1,59 @@
+package logruslog
+
+import (
+	"bytes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/vsysa/logging"
+	"testing"
+)
+
+func TestRoutingHook(t *testing.T) {
+	notice := logging.MustRegisterLevel("route-notice", 13, logging.Green)
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	logger := NewLogrusLoggerWithRoutes(logging.Routes{
+		{Level: logging.TraceLevel, Writer: out},
+		{Level: notice, Writer: errOut},
+	})
+	logger.SetLevel(logging.TraceLevel)
+
+	tests := []struct {
+		name     string
+		log      func()
+		expected *bytes.Buffer
+	}{
+		{"Info", func() { logger.Info("message with WARN[ and ERRO[ inside") }, out},
+		{"Debug", func() { logger.Debug("debug") }, out},
+		{"Custom level", func() { logger.Log(notice, "notice") }, errOut},
+		{"Warn", func() { logger.Warn("warn") }, errOut},
+		{"Panic", func() { logger.Log(logging.PanicLevel, "panic") }, errOut},
+		{"Spoofed level name", func() { logger.Infow("info", LevelNameKey, "error") }, out},
+		{"Plain logrus entry", func() { logger.logrus.Warn("warn") }, errOut},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out.Reset()
+			errOut.Reset()
+			tt.log()
+			assert.NotEmpty(t, tt.expected.String())
+			assert.Equal(t, out.Len()+errOut.Len(), tt.expected.Len(), "Entry should be written exactly once")
+		})
+	}
+}
+
+func TestRoutingHook_JSONFormatter(t *testing.T) {
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	logger := NewLogrusLoggerWithRoutes(logging.Routes{
+		{Level: logging.TraceLevel, Writer: out},
+		{Level: logging.WarnLevel, Writer: errOut},
+	})
+	logger.logrus.SetFormatter(&logrus.JSONFormatter{})
+
+	logger.Info("info")
+	logger.Error("error")
+
+	assert.Contains(t, out.String(), `"msg":"info"`)
+	assert.NotContains(t, out.String(), `"msg":"error"`)
+	assert.Contains(t, errOut.String(), `"msg":"error"`)
+}
@@ -0,0 +1,177 @@
+package sloglog
+
+import (
+	"context"
+	"github.com/vsysa/logging"
+	"log/slog"
+	"slices"
+)
+
+// HandlerOptions настраивает Handler.
+type HandlerOptions struct {
+	// Level задает минимальный уровень записей, которые Handler передает в логгер,
+	// в дополнение к уровню самого логгера.
+	Level slog.Leveler
+}
+
+// Handler реализует slog.Handler поверх любого logging.Logger, чтобы библиотеки,
+// принимающие *slog.Logger, писали через настроенный бэкенд и его контекст.
+//
+// Атрибуты записи и WithAttrs добавляются в контекст клона логгера так же, как
+// через AddContexts; группы превращаются во вложенные map[string]interface{}.
+// Уровни выше slog.LevelError пишутся как Error, Handler никогда не завершает процесс.
+type Handler struct {
+	logger logging.Logger
+	level  slog.Leveler
+	attrs  map[string]interface{}
+	groups []string
+}
+
+func NewHandler(logger logging.Logger, opts *HandlerOptions) *Handler {
+	h := &Handler{
+		logger: logger,
+		attrs:  make(map[string]interface{}),
+	}
+	if opts != nil {
+		h.level = opts.Level
+	}
+	return h
+}
+
+// Enabled учитывает уровень запроса из ctx (см. logging.LevelFromContext), как и Handle.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.level != nil && level < h.level.Level() {
+		return false
+	}
+	logger := h.logger
+	if ctx != nil {
+		if _, ok := logging.LevelFromContext(ctx); ok {
+			logger = logger.SetCtx(ctx)
+		}
+	}
+	return logger.Enabled(toLoggingLevel(level))
+}
+
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	fields := copyFields(h.attrs)
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	addGroupAttrs(fields, h.groups, attrs)
+
+	logger := h.logger.Clone()
+	// место вызова берется из записи slog, иначе им оказался бы сам Handle
+	if setter, ok := logger.(logging.CallerSetter); ok && record.PC != 0 {
+		logger = setter.WithCaller(record.PC)
+	}
+	logger = logger.SetCtx(ctx).AddContexts(fields)
+	switch toLoggingLevel(record.Level) {
+	case logging.TraceLevel:
+		logger.Trace(record.Message)
+	case logging.DebugLevel:
+		logger.Debug(record.Message)
+	case logging.InfoLevel:
+		logger.Info(record.Message)
+	case logging.WarnLevel:
+		logger.Warn(record.Message)
+	default:
+		logger.Error(record.Message)
+	}
+	return nil
+}
+
+// toLoggingLevel переводит уровень slog в ближайший не более строгий logging.Level.
+func toLoggingLevel(level slog.Level) logging.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return logging.TraceLevel
+	case level < slog.LevelInfo:
+		return logging.DebugLevel
+	case level < slog.LevelWarn:
+		return logging.InfoLevel
+	case level < slog.LevelError:
+		return logging.WarnLevel
+	default:
+		return logging.ErrorLevel
+	}
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	clone := h.clone()
+	addGroupAttrs(clone.attrs, clone.groups, attrs)
+	return clone
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := h.clone()
+	clone.groups = append(clone.groups, name)
+	return clone
+}
+
+func (h *Handler) clone() *Handler {
+	return &Handler{
+		logger: h.logger,
+		level:  h.level,
+		attrs:  copyFields(h.attrs),
+		groups: slices.Clip(h.groups),
+	}
+}
+
+// addGroupAttrs добавляет attrs в fields по пути groups. Группа без атрибутов не создается.
+func addGroupAttrs(fields map[string]interface{}, groups []string, attrs []slog.Attr) {
+	if len(groups) == 0 {
+		for _, a := range attrs {
+			addAttr(fields, a)
+		}
+		return
+	}
+
+	sub, ok := fields[groups[0]].(map[string]interface{})
+	if !ok {
+		sub = make(map[string]interface{})
+	}
+	addGroupAttrs(sub, groups[1:], attrs)
+	if len(sub) > 0 {
+		fields[groups[0]] = sub
+	}
+}
+
+func addAttr(fields map[string]interface{}, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		fields[a.Key] = a.Value.Any()
+		return
+	}
+
+	if a.Key == "" {
+		for _, ga := range a.Value.Group() {
+			addAttr(fields, ga)
+		}
+		return
+	}
+	addGroupAttrs(fields, []string{a.Key}, a.Value.Group())
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	newFields := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if sub, ok := value.(map[string]interface{}); ok {
+			value = copyFields(sub)
+		}
+		newFields[key] = value
+	}
+	return newFields
+}
+
+var _ slog.Handler = &Handler{}
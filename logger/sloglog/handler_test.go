@@ -0,0 +1,100 @@
+package sloglog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"log/slog"
+	"testing"
+)
+
+type secret string
+
+func (s secret) LogValue() slog.Value {
+	return slog.StringValue("***")
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var entries []map[string]interface{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var entry map[string]interface{}
+		require.NoError(t, dec.Decode(&entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestHandler_AttrsAndGroups(t *testing.T) {
+	backend, _, buf := getSlogLogger()
+	backend.AddContext("service", "api")
+
+	logger := slog.New(NewHandler(backend, nil)).
+		With("component", "db").
+		WithGroup("req").
+		With("id", 7)
+	logger.Info("query", "password", secret("qwerty"), slog.Group("", "inline", true))
+	logger.WithGroup("empty").Info("no attrs")
+
+	entries := decodeLines(t, buf)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "query", entries[0]["msg"])
+	assert.Equal(t, "api", entries[0]["service"])
+	assert.Equal(t, "db", entries[0]["component"])
+	assert.Equal(t, map[string]interface{}{"id": float64(7), "password": "***", "inline": true}, entries[0]["req"])
+
+	assert.Equal(t, map[string]interface{}{"id": float64(7)}, entries[1]["req"])
+	assert.NotContains(t, entries[1], "empty")
+
+	assert.Equal(t, map[string]interface{}{"service": "api"}, backend.GetAllContexts(), "Wrapped logger context should remain unchanged")
+}
+
+func TestHandler_Levels(t *testing.T) {
+	backend, _, buf := getSlogLogger()
+	backend.SetLevel(logging.TraceLevel)
+
+	levelVar := &slog.LevelVar{}
+	levelVar.Set(slog.LevelInfo)
+	handler := NewHandler(backend, &HandlerOptions{Level: levelVar})
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelWarn))
+
+	logger := slog.New(handler)
+	logger.Debug("filtered")
+	logger.Warn("warn")
+	logger.Log(context.Background(), slog.LevelError+8, "above error")
+
+	entries := decodeLines(t, buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "WARN", entries[0]["level"])
+	assert.Equal(t, "ERROR", entries[1]["level"])
+}
+
+func TestHandler_EnabledFollowsLogger(t *testing.T) {
+	backend, _, _ := getSlogLogger()
+	backend.SetLevel(logging.WarnLevel)
+	handler := NewHandler(backend, nil)
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelError))
+}
+
+func TestHandler_EnabledRequestLevel(t *testing.T) {
+	backend, _, buf := getSlogLogger()
+	backend.SetLevel(logging.InfoLevel)
+	logger := slog.New(NewHandler(backend, nil))
+	ctx := logging.ContextWithLevel(context.Background(), logging.DebugLevel)
+
+	logger.DebugContext(ctx, "request debug")
+	logger.Debug("plain debug")
+
+	entries := decodeLines(t, buf)
+	require.Len(t, entries, 1, "Request level from ctx should enable the record")
+	assert.Equal(t, "request debug", entries[0]["msg"])
+	assert.Equal(t, logging.InfoLevel, backend.GetLevel(), "Request level should not change the wrapped logger")
+}
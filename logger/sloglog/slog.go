@@ -0,0 +1,388 @@
+package sloglog
+
+import (
+	"context"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/internal/helper"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Уровни slog, которых нет в стандартной библиотеке.
+const (
+	LevelTrace = slog.LevelDebug - 4
+	LevelPanic = slog.LevelError + 2
+	LevelFatal = slog.LevelError + 4
+)
+
+var levelMap = map[logging.Level]slog.Level{
+	logging.TraceLevel: LevelTrace,
+	logging.DebugLevel: slog.LevelDebug,
+	logging.InfoLevel:  slog.LevelInfo,
+	logging.WarnLevel:  slog.LevelWarn,
+	logging.ErrorLevel: slog.LevelError,
+	logging.PanicLevel: LevelPanic,
+	logging.FatalLevel: LevelFatal,
+}
+
+var levelNames = map[slog.Level]string{
+	LevelTrace: "TRACE",
+	LevelPanic: "PANIC",
+	LevelFatal: "FATAL",
+}
+
+// toSlogLevel переводит уровень logging в slog.Level. Шаг между стандартными уровнями
+// у них одинаковый, поэтому пользовательский уровень получает значение между соседними
+// уровнями slog, например Notice (14) -> INFO+2.
+func toSlogLevel(level logging.Level) (slog.Level, bool) {
+	if slogLevel, ok := levelMap[level]; ok {
+		return slogLevel, true
+	}
+	if !level.Registered() {
+		return 0, false
+	}
+	return slog.Level(level - logging.InfoLevel), true
+}
+
+// ReplaceLevelNames подходит для slog.HandlerOptions.ReplaceAttr и выводит
+// TRACE, PANIC, FATAL и имена пользовательских уровней вместо "DEBUG-4", "ERROR+2",
+// "ERROR+4" и "INFO+2".
+func ReplaceLevelNames(_ []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.LevelKey {
+		return a
+	}
+	if level, ok := a.Value.Any().(slog.Level); ok {
+		if name, ok := levelNames[level]; ok {
+			a.Value = slog.StringValue(name)
+		} else if custom := logging.Level(level) + logging.InfoLevel; custom.Registered() {
+			a.Value = slog.StringValue(strings.ToUpper(custom.String()))
+		}
+	}
+	return a
+}
+
+type SlogLogger struct {
+	handler slog.Handler
+	// floor хранит уровень бэкенда и опускает LevelVar для клонов с более низким
+	// собственным уровнем (см. helper.LevelFloor)
+	floor *helper.LevelFloor[slog.Level]
+	// ctx передается в Handle. Задается только при создании логгера (см. SetCtx) и
+	// после этого не меняется, поэтому читается без блокировки.
+	ctx       context.Context
+	contextMu sync.RWMutex
+	context   map[string]interface{}
+	name      string
+	level     *helper.LevelNode[slog.Level]
+	exitFunc  logging.ExitFunc
+	// callerSkip — дополнительные кадры над публичным методом (см. WithCallerSkip),
+	// caller — заданное через WithCaller место вызова.
+	callerSkip int
+	caller     uintptr
+}
+
+// NewSlogLogger оборачивает handler, который проверяет уровень по levelVar. Клон с уровнем
+// ниже уровня бэкенда опускает levelVar до своего уровня на время проверки своей записи,
+// а записи остальных логгеров отсекает сам SlogLogger, поэтому уровень следует менять через SetLevel. handler.Enabled
+// проверяется для каждой записи.
+func NewSlogLogger(handler slog.Handler, levelVar *slog.LevelVar) *SlogLogger {
+	if levelVar == nil {
+		levelVar = &slog.LevelVar{}
+	}
+	if handler == nil {
+		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       levelVar,
+			ReplaceAttr: ReplaceLevelNames,
+		})
+	}
+
+	floor := helper.NewLevelFloor(levelVar.Level, levelVar.Set)
+	return &SlogLogger{
+		handler:  handler,
+		floor:    floor,
+		ctx:      context.Background(),
+		context:  make(map[string]interface{}),
+		level:    helper.NewLevelNode(floor.Level),
+		exitFunc: os.Exit,
+	}
+}
+
+// CONTEXT
+
+func (r *SlogLogger) AddContext(key string, value interface{}) logging.Logger {
+	r.contextMu.Lock()
+	defer r.contextMu.Unlock()
+	r.context[key] = helper.NormalizeValue(value)
+	return r
+}
+
+func (r *SlogLogger) AddContexts(contexts map[string]interface{}) logging.Logger {
+	r.contextMu.Lock()
+	defer r.contextMu.Unlock()
+	for key, value := range contexts {
+		r.context[key] = helper.NormalizeValue(value)
+	}
+	return r
+}
+
+func (r *SlogLogger) DeleteContext(key string) logging.Logger {
+	r.contextMu.Lock()
+	defer r.contextMu.Unlock()
+	delete(r.context, key)
+	return r
+}
+
+func (r *SlogLogger) GetAllContexts() map[string]interface{} {
+	r.contextMu.RLock()
+	defer r.contextMu.RUnlock()
+	return helper.CopyMapContext(r.context)
+}
+
+// LOGGING
+
+// SetLevel меняет slog.LevelVar для корневого логгера и собственный уровень для клона.
+func (r *SlogLogger) SetLevel(level logging.Level) {
+	if slogLevel, ok := levelMap[level]; !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	} else if r.level.IsRoot() {
+		r.floor.SetLevel(slogLevel)
+	} else {
+		r.level.SetOverride(slogLevel)
+	}
+}
+
+func (r *SlogLogger) ResetLevel() {
+	r.level.ClearOverride()
+}
+
+// HasLevelOverride сообщает, задан ли логгеру собственный уровень (см. ResetLevel).
+func (r *SlogLogger) HasLevelOverride() bool {
+	return r.level.HasOverride()
+}
+
+// GetLevel возвращает действующий уровень логгера с учетом наследования.
+func (r *SlogLogger) GetLevel() logging.Level {
+	return helper.LevelFromBackend(levelMap, r.level.Level(), true)
+}
+
+// Enabled не меняет LevelVar: для уровня ниже уровня бэкенда handler.Enabled проверяется
+// на уровне бэкенда, которым LevelVar ограничивает handler, пока log его не опустит.
+func (r *SlogLogger) Enabled(level logging.Level) bool {
+	slogLevel, ok := toSlogLevel(level)
+	return ok && slogLevel >= r.level.Level() && r.handler.Enabled(r.ctx, max(slogLevel, r.floor.Level()))
+}
+
+func (r *SlogLogger) Trace(message string, a ...any) {
+	r.log(LevelTrace, helper.FormatMessage(message, a), nil)
+}
+
+func (r *SlogLogger) Debug(message string, a ...any) {
+	r.log(slog.LevelDebug, helper.FormatMessage(message, a), nil)
+}
+
+func (r *SlogLogger) Info(message string, a ...any) {
+	r.log(slog.LevelInfo, helper.FormatMessage(message, a), nil)
+}
+
+func (r *SlogLogger) Warn(message string, a ...any) {
+	r.log(slog.LevelWarn, helper.FormatMessage(message, a), nil)
+}
+
+func (r *SlogLogger) Error(message string, a ...any) {
+	r.log(slog.LevelError, helper.FormatMessage(message, a), nil)
+}
+
+func (r *SlogLogger) Fatal(message string, a ...any) {
+	r.log(LevelFatal, helper.FormatMessage(message, a), nil)
+	r.exit()
+}
+
+func (r *SlogLogger) ErrorCatch(err error, message string, a ...any) {
+	if r.Enabled(logging.ErrorLevel) {
+		r.log(slog.LevelError, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.ErrorLevel, r.callerSkip))
+	}
+}
+
+func (r *SlogLogger) FatalCatch(err error, message string, a ...any) {
+	if r.Enabled(logging.FatalLevel) {
+		r.log(LevelFatal, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.FatalLevel, r.callerSkip))
+	}
+	r.exit()
+}
+
+func (r *SlogLogger) Panic(message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	r.log(LevelPanic, msg, nil)
+	panic(msg)
+}
+
+func (r *SlogLogger) PanicCatch(err error, message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	if r.Enabled(logging.PanicLevel) {
+		r.log(LevelPanic, msg, helper.ErrorFields(err, logging.PanicLevel, r.callerSkip))
+	}
+	if err != nil {
+		panic(err)
+	}
+	panic(msg)
+}
+
+func (r *SlogLogger) Tracew(message string, keysAndValues ...any) {
+	r.log(LevelTrace, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *SlogLogger) Debugw(message string, keysAndValues ...any) {
+	r.log(slog.LevelDebug, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *SlogLogger) Infow(message string, keysAndValues ...any) {
+	r.log(slog.LevelInfo, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *SlogLogger) Warnw(message string, keysAndValues ...any) {
+	r.log(slog.LevelWarn, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *SlogLogger) Errorw(message string, keysAndValues ...any) {
+	r.log(slog.LevelError, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *SlogLogger) Fatalw(message string, keysAndValues ...any) {
+	r.log(LevelFatal, message, helper.KeysAndValues(keysAndValues))
+	r.exit()
+}
+
+// Log пишет запись на уровне level. Пользовательский уровень получает собственное
+// значение slog.Level (см. ReplaceLevelNames).
+func (r *SlogLogger) Log(level logging.Level, message string, a ...any) {
+	slogLevel, ok := toSlogLevel(level)
+	if !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(slogLevel, helper.FormatMessage(message, a), nil)
+	if level == logging.FatalLevel {
+		r.exit()
+	}
+}
+
+func (r *SlogLogger) Logw(level logging.Level, message string, keysAndValues ...any) {
+	slogLevel, ok := toSlogLevel(level)
+	if !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(slogLevel, message, helper.KeysAndValues(keysAndValues))
+	if level == logging.FatalLevel {
+		r.exit()
+	}
+}
+
+// BASE
+
+func (r *SlogLogger) Clone() logging.Logger {
+	return r.derive(r.GetAllContexts())
+}
+
+func (r *SlogLogger) With(keysAndValues ...any) logging.Logger {
+	return r.derive(helper.MergeContext(r.GetAllContexts(), helper.KeysAndValues(keysAndValues)))
+}
+
+func (r *SlogLogger) Named(name string) logging.Logger {
+	fullName := logging.JoinName(r.name, name)
+	child := r.derive(helper.MergeContext(r.GetAllContexts(), map[string]interface{}{logging.LoggerNameKey: fullName}))
+	child.name = fullName
+	child.level = r.level.ChildWithLookup(helper.NamedLevelLookup(fullName, levelMap))
+	return child
+}
+
+// derive создает дочерний логгер с общим handler, наследующий уровень r.
+func (r *SlogLogger) derive(context map[string]interface{}) *SlogLogger {
+	return &SlogLogger{
+		handler:    r.handler,
+		floor:      r.floor,
+		ctx:        r.ctx,
+		context:    context,
+		name:       r.name,
+		level:      r.level.Child(),
+		exitFunc:   r.exitFunc,
+		callerSkip: r.callerSkip,
+		caller:     r.caller,
+	}
+}
+
+func (r *SlogLogger) WithCallerSkip(skip int) logging.Logger {
+	child := r.derive(r.GetAllContexts())
+	child.callerSkip += skip
+	return child
+}
+
+func (r *SlogLogger) WithCaller(pc uintptr) logging.Logger {
+	child := r.derive(r.GetAllContexts())
+	child.caller = pc
+	return child
+}
+
+// SetCtx возвращает производный логгер, привязанный к ctx: с traceID и spanID активного
+// спана и уровнем запроса, если он есть в ctx (см. logging.LevelFromContext). ctx
+// передается в slog.Handler.Handle. r не изменяется.
+func (r *SlogLogger) SetCtx(ctx context.Context) logging.Logger {
+	child := r.derive(helper.MergeContext(r.GetAllContexts(), helper.SpanFields(ctx)))
+	child.ctx = ctx
+	if level, ok := helper.ContextLevel(ctx, levelMap); ok {
+		child.level.SetOverride(level)
+	}
+	return child
+}
+
+func (r *SlogLogger) SetExitFunc(exitFunc logging.ExitFunc) {
+	r.exitFunc = exitFunc
+}
+
+// Sync сбрасывает handler, если он реализует Sync() error: в интерфейсе slog.Handler
+// такого метода нет.
+func (r *SlogLogger) Sync() error {
+	if syncer, ok := r.handler.(interface{ Sync() error }); ok {
+		return helper.SyncError(syncer.Sync())
+	}
+	return nil
+}
+
+func (r *SlogLogger) exit() {
+	_ = r.Sync()
+	r.exitFunc(1)
+}
+
+func (r *SlogLogger) log(level slog.Level, message string, fields map[string]interface{}) {
+	if level < r.level.Level() {
+		return
+	}
+	// клон с уровнем ниже уровня бэкенда опускает LevelVar на время handler.Enabled
+	restore := r.floor.Lower(level)
+	enabled := r.handler.Enabled(r.ctx, level)
+	restore()
+	if !enabled {
+		return
+	}
+
+	record := slog.NewRecord(time.Now(), level, message, r.callerPC())
+	for key, value := range helper.MergeContext(r.GetAllContexts(), fields) {
+		record.AddAttrs(slog.Any(key, value))
+	}
+	_ = r.handler.Handle(r.ctx, record)
+}
+
+// callerPC возвращает место вызова публичного метода логгера. Вызывается из log.
+func (r *SlogLogger) callerPC() uintptr {
+	if r.caller != 0 {
+		return r.caller
+	}
+	return helper.CallerPC(3 + r.callerSkip)
+}
+
+var _ logging.Logger = &SlogLogger{}
+var _ logging.CallerSetter = &SlogLogger{}
@@ -0,0 +1,177 @@
+package sloglog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func getSlogLogger() (*SlogLogger, *slog.LevelVar, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	levelVar := &slog.LevelVar{}
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level:       levelVar,
+		ReplaceAttr: ReplaceLevelNames,
+	})
+	return NewSlogLogger(handler, levelVar), levelVar, buf
+}
+
+func TestBaseLogger_SetLevel(t *testing.T) {
+	logger, lv, _ := getSlogLogger()
+	logger.SetLevel(logging.DebugLevel)
+	assert.Equal(t, slog.LevelDebug, lv.Level(), "Log level should be set to Debug")
+}
+
+func TestSlogLogger_TraceLevel(t *testing.T) {
+	logger, _, buf := getSlogLogger()
+
+	logger.SetLevel(logging.DebugLevel)
+	logger.Trace("hidden")
+	assert.Empty(t, buf.String(), "Trace should be filtered at Debug level")
+
+	logger.SetLevel(logging.TraceLevel)
+	logger.AddContext("count", 3)
+	logger.Trace("visible")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "TRACE", entry["level"])
+	assert.Equal(t, "visible", entry["msg"])
+	assert.Equal(t, float64(3), entry["count"])
+}
+
+func TestSlogLogger_CustomLevel(t *testing.T) {
+	notice := logging.MustRegisterLevel("notice", 14, logging.Green)
+	logger, _, buf := getSlogLogger()
+	logger.SetLevel(logging.InfoLevel)
+
+	logger.Logw(notice, "custom", "key", "value")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "NOTICE", entry["level"])
+	assert.Equal(t, "value", entry["key"])
+
+	buf.Reset()
+	logger.SetLevel(logging.WarnLevel)
+	assert.False(t, logger.Enabled(notice))
+	logger.Log(notice, "hidden")
+	assert.Empty(t, buf.String())
+}
+
+type ctxKey struct{}
+
+// ctxHandler запоминает значение ctxKey из контекста каждой записи.
+type ctxHandler struct {
+	mu     sync.Mutex
+	values []interface{}
+}
+
+func (h *ctxHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *ctxHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *ctxHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *ctxHandler) Handle(ctx context.Context, _ slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.values = append(h.values, ctx.Value(ctxKey{}))
+	return nil
+}
+
+func TestSlogLogger_SetCtx(t *testing.T) {
+	handler := &ctxHandler{}
+	logger := NewSlogLogger(handler, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			logger.SetCtx(context.WithValue(context.Background(), ctxKey{}, i)).Info("request")
+		}()
+		go func() {
+			defer wg.Done()
+			logger.Info("plain")
+		}()
+	}
+	wg.Wait()
+
+	handler.values = nil
+	logger.Info("plain")
+	logger.SetCtx(context.WithValue(context.Background(), ctxKey{}, "request")).Info("request")
+	assert.Equal(t, []interface{}{nil, "request"}, handler.values, "Only the derived logger should carry ctx")
+}
+
+// minLevelHandler добавляет к handler собственный минимальный уровень.
+type minLevelHandler struct {
+	slog.Handler
+	min slog.Level
+}
+
+func (h minLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.min && h.Handler.Enabled(ctx, level)
+}
+
+func TestSlogLogger_RequestLevelDoesNotLeak(t *testing.T) {
+	buf := &bytes.Buffer{}
+	levelVar := &slog.LevelVar{}
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: levelVar})
+	logger := NewSlogLogger(handler, levelVar)
+
+	requestLogger := logger.SetCtx(logging.ContextWithLevel(context.Background(), logging.DebugLevel))
+	requestLogger.Debug("request debug")
+	assert.Contains(t, buf.String(), "request debug")
+	assert.Equal(t, slog.LevelInfo, levelVar.Level(), "Shared level should be restored after the entry")
+
+	slog.New(handler).Debug("raw debug")
+	assert.NotContains(t, buf.String(), "raw debug", "Loggers sharing the handler should not see the lowered level")
+}
+
+// levelSpyHandler запоминает значение LevelVar при каждом вызове Enabled.
+type levelSpyHandler struct {
+	slog.Handler
+	levelVar *slog.LevelVar
+	seen     []slog.Level
+}
+
+func (h *levelSpyHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	h.seen = append(h.seen, h.levelVar.Level())
+	return h.Handler.Enabled(ctx, level)
+}
+
+func TestSlogLogger_EnabledHasNoSideEffects(t *testing.T) {
+	levelVar := &slog.LevelVar{}
+	spy := &levelSpyHandler{Handler: slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: levelVar}), levelVar: levelVar}
+	logger := NewSlogLogger(spy, levelVar)
+	child := logger.Clone()
+	child.SetLevel(logging.DebugLevel)
+
+	assert.True(t, child.Enabled(logging.DebugLevel))
+	assert.False(t, logger.Enabled(logging.DebugLevel))
+	assert.Equal(t, []slog.Level{slog.LevelInfo}, spy.seen, "Enabled should not lower the shared LevelVar")
+}
+
+func TestSlogLogger_CloneLevelKeepsHandlerEnabled(t *testing.T) {
+	buf := &bytes.Buffer{}
+	levelVar := &slog.LevelVar{}
+	levelVar.Set(slog.LevelWarn)
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: levelVar})
+	logger := NewSlogLogger(minLevelHandler{Handler: handler, min: slog.LevelInfo}, levelVar)
+
+	child := logger.Clone()
+	child.SetLevel(logging.DebugLevel)
+	child.Debug("child debug")
+	assert.Empty(t, buf.String(), "Handler's own level should still apply to clones")
+
+	child.Info("child info")
+	logger.Info("parent info")
+	assert.Contains(t, buf.String(), "child info")
+	assert.NotContains(t, buf.String(), "parent info")
+	assert.Equal(t, logging.WarnLevel, logger.GetLevel())
+}
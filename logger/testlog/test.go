@@ -5,14 +5,26 @@ import (
 	"fmt"
 	"github.com/vsysa/logging"
 	"github.com/vsysa/logging/internal/helper"
-	"os"
 	"sync"
 )
 
+// levelMap нужен для helper.NamedLevelLookup: TestLogger хранит уровни logging как есть.
+var levelMap = map[logging.Level]logging.Level{
+	logging.TraceLevel: logging.TraceLevel,
+	logging.DebugLevel: logging.DebugLevel,
+	logging.InfoLevel:  logging.InfoLevel,
+	logging.WarnLevel:  logging.WarnLevel,
+	logging.ErrorLevel: logging.ErrorLevel,
+	logging.PanicLevel: logging.PanicLevel,
+	logging.FatalLevel: logging.FatalLevel,
+}
+
 type logStoreStruct struct {
 	level   logging.Level
 	message string
 	context map[string]interface{}
+	// caller — место вызова записи, с которым она выводится в ShowStoredLogs
+	caller uintptr
 }
 
 type TestLogger struct {
@@ -20,17 +32,31 @@ type TestLogger struct {
 
 	contextMu sync.RWMutex
 	context   map[string]interface{}
+	name      string
+	level     *helper.LevelNode[logging.Level]
 
 	logStoreMu   sync.RWMutex
 	logStore     []logStoreStruct
 	parentLogger *TestLogger
 	rootLogger   *TestLogger
+	exitFunc     logging.ExitFunc
+	// callerSkip — дополнительные кадры над публичным методом (см. WithCallerSkip),
+	// caller — заданное через WithCaller место вызова.
+	callerSkip int
+	caller     uintptr
 }
 
+// NewTestLogger создает TestLogger, который выводит записи в outLogger через ShowStoredLogs.
+// Fatal-записи по умолчанию только сохраняются и не завершают тест; ExitFunc можно задать
+// через SetExitFunc.
 func NewTestLogger(outLogger logging.Logger) *TestLogger {
 	return &TestLogger{
 		outLogger: outLogger,
 		context:   make(map[string]interface{}),
+		level: helper.NewLevelNode(func() logging.Level {
+			return logging.TraceLevel
+		}),
+		exitFunc: func(int) {},
 	}
 }
 
@@ -46,7 +72,7 @@ func NewTestLoggerWithParams(outLogger logging.Logger, params map[string]interfa
 func (r *TestLogger) AddContext(key string, value interface{}) logging.Logger {
 	r.contextMu.Lock()
 	defer r.contextMu.Unlock()
-	r.context[key] = fmt.Sprintf("%v", value)
+	r.context[key] = helper.NormalizeValue(value)
 	return r
 }
 
@@ -55,7 +81,7 @@ func (r *TestLogger) AddContexts(contexts map[string]interface{}) logging.Logger
 	r.contextMu.Lock()
 	defer r.contextMu.Unlock()
 	for key, value := range contexts {
-		r.context[key] = fmt.Sprintf("%v", value)
+		r.context[key] = helper.NormalizeValue(value)
 	}
 	return r
 }
@@ -80,49 +106,130 @@ func (r *TestLogger) GetAllContexts() map[string]interface{} {
 
 //	LOGGING
 
+// SetLevel задает порог, ниже которого записи не сохраняются. По умолчанию сохраняется все,
+// начиная с Trace; outLogger порог не затрагивает.
 func (r *TestLogger) SetLevel(level logging.Level) {
-	//r.outLogger.SetLevel(level)
+	r.level.SetOverride(level)
+}
+
+func (r *TestLogger) ResetLevel() {
+	r.level.ClearOverride()
+}
+
+// HasLevelOverride сообщает, задан ли логгеру собственный уровень (см. ResetLevel).
+func (r *TestLogger) HasLevelOverride() bool {
+	return r.level.HasOverride()
+}
+
+func (r *TestLogger) GetLevel() logging.Level {
+	return r.level.Level()
+}
+
+func (r *TestLogger) Enabled(level logging.Level) bool {
+	return level >= r.level.Level()
 }
 
 func (r *TestLogger) Trace(message string, a ...any) {
-	r.log(logging.TraceLevel, fmt.Sprintf(message, a...))
+	r.log(logging.TraceLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *TestLogger) Debug(message string, a ...any) {
-	r.log(logging.DebugLevel, fmt.Sprintf(message, a...))
+	r.log(logging.DebugLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *TestLogger) Info(message string, a ...any) {
-	r.log(logging.InfoLevel, fmt.Sprintf(message, a...))
+	r.log(logging.InfoLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *TestLogger) Warn(message string, a ...any) {
-	r.log(logging.WarnLevel, fmt.Sprintf(message, a...))
+	r.log(logging.WarnLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *TestLogger) Error(message string, a ...any) {
-	r.log(logging.ErrorLevel, fmt.Sprintf(message, a...))
+	r.log(logging.ErrorLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *TestLogger) Fatal(message string, a ...any) {
-	r.log(logging.FatalLevel, fmt.Sprintf(message, a...))
+	r.log(logging.FatalLevel, helper.FormatMessage(message, a), nil)
+	r.exit()
 }
 
 func (r *TestLogger) ErrorCatch(err error, message string, a ...any) {
-	msg := fmt.Sprintf(message, a...)
-	if err != nil {
-		msg += ": " + err.Error()
+	if r.Enabled(logging.ErrorLevel) {
+		r.log(logging.ErrorLevel, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.ErrorLevel, r.callerSkip))
 	}
-	r.log(logging.ErrorLevel, msg)
 }
 
 func (r *TestLogger) FatalCatch(err error, message string, a ...any) {
-	msg := fmt.Sprintf(message, a...)
+	if r.Enabled(logging.FatalLevel) {
+		r.log(logging.FatalLevel, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.FatalLevel, r.callerSkip))
+	}
+	r.exit()
+}
+
+func (r *TestLogger) Panic(message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	r.log(logging.PanicLevel, msg, nil)
+	panic(msg)
+}
+
+func (r *TestLogger) PanicCatch(err error, message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	if r.Enabled(logging.PanicLevel) {
+		r.log(logging.PanicLevel, msg, helper.ErrorFields(err, logging.PanicLevel, r.callerSkip))
+	}
 	if err != nil {
-		msg += ": " + err.Error()
+		panic(err)
+	}
+	panic(msg)
+}
+
+func (r *TestLogger) Tracew(message string, keysAndValues ...any) {
+	r.log(logging.TraceLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *TestLogger) Debugw(message string, keysAndValues ...any) {
+	r.log(logging.DebugLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *TestLogger) Infow(message string, keysAndValues ...any) {
+	r.log(logging.InfoLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *TestLogger) Warnw(message string, keysAndValues ...any) {
+	r.log(logging.WarnLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *TestLogger) Errorw(message string, keysAndValues ...any) {
+	r.log(logging.ErrorLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *TestLogger) Fatalw(message string, keysAndValues ...any) {
+	r.log(logging.FatalLevel, message, helper.KeysAndValues(keysAndValues))
+	r.exit()
+}
+
+// Log сохраняет запись на уровне level как есть, в том числе пользовательском.
+func (r *TestLogger) Log(level logging.Level, message string, a ...any) {
+	if !level.Registered() {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(level, helper.FormatMessage(message, a), nil)
+	if level == logging.FatalLevel {
+		r.exit()
+	}
+}
+
+func (r *TestLogger) Logw(level logging.Level, message string, keysAndValues ...any) {
+	if !level.Registered() {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(level, message, helper.KeysAndValues(keysAndValues))
+	if level == logging.FatalLevel {
+		r.exit()
 	}
-	r.log(logging.FatalLevel, msg)
-	os.Exit(1)
 }
 
 // BASE
@@ -138,11 +245,51 @@ func (r *TestLogger) Clone() logging.Logger {
 	return &TestLogger{
 		outLogger:    r.outLogger,
 		context:      helper.CopyMapContext(r.context),
+		name:         r.name,
+		level:        r.level.Child(),
 		parentLogger: r,
 		rootLogger:   rootLogger,
+		exitFunc:     r.exitFunc,
+		callerSkip:   r.callerSkip,
+		caller:       r.caller,
 	}
 }
 
+func (r *TestLogger) WithCallerSkip(skip int) logging.Logger {
+	child := r.Clone().(*TestLogger)
+	child.callerSkip += skip
+	return child
+}
+
+func (r *TestLogger) WithCaller(pc uintptr) logging.Logger {
+	child := r.Clone().(*TestLogger)
+	child.caller = pc
+	return child
+}
+
+// With возвращает дочерний логгер, записи которого сохраняются в корневой TestLogger, как у Clone.
+func (r *TestLogger) With(keysAndValues ...any) logging.Logger {
+	child := r.Clone().(*TestLogger)
+	child.context = helper.MergeContext(child.context, helper.KeysAndValues(keysAndValues))
+	return child
+}
+
+// SetExitFunc задает ExitFunc, вызываемый после Fatal-записи. Дочерние логгеры, созданные
+// после вызова, наследуют его.
+func (r *TestLogger) SetExitFunc(exitFunc logging.ExitFunc) {
+	r.exitFunc = exitFunc
+}
+
+func (r *TestLogger) Named(name string) logging.Logger {
+	child := r.Clone().(*TestLogger)
+	child.name = logging.JoinName(r.name, name)
+	child.context[logging.LoggerNameKey] = child.name
+	child.level = r.level.ChildWithLookup(helper.NamedLevelLookup(child.name, levelMap))
+	return child
+}
+
+// ShowStoredLogs выводит сохраненные записи в outLogger. Если outLogger реализует
+// logging.CallerSetter, записи выводятся с исходным местом вызова.
 func (r *TestLogger) ShowStoredLogs() {
 	r.logStoreMu.RLock()
 	defer r.logStoreMu.RUnlock()
@@ -150,8 +297,17 @@ func (r *TestLogger) ShowStoredLogs() {
 	for _, storedLog := range r.logStore {
 		// TODO что-то придумать бы поэлегантней
 		localLogger := r.outLogger.Clone()
+		if setter, ok := localLogger.(logging.CallerSetter); ok && storedLog.caller != 0 {
+			localLogger = setter.WithCaller(storedLog.caller)
+		}
+		if setter, ok := localLogger.(logging.ExitFuncSetter); ok {
+			// повтор Fatal-записи не должен завершать процесс
+			setter.SetExitFunc(func(int) {})
+		}
 		localLogger.AddContexts(storedLog.context)
 		switch storedLog.level {
+		case logging.TraceLevel:
+			localLogger.Trace(storedLog.message)
 		case logging.DebugLevel:
 			localLogger.Debug(storedLog.message)
 		case logging.InfoLevel:
@@ -162,13 +318,15 @@ func (r *TestLogger) ShowStoredLogs() {
 			localLogger.Error(storedLog.message)
 		case logging.FatalLevel:
 			localLogger.Fatal(storedLog.message)
+		default:
+			localLogger.Log(storedLog.level, storedLog.message)
 		}
 	}
 }
 
-func (r *TestLogger) storeLog(level logging.Level, message string, context map[string]interface{}) {
+func (r *TestLogger) storeLog(level logging.Level, message string, context map[string]interface{}, caller uintptr) {
 	if r.parentLogger != nil {
-		r.parentLogger.storeLog(level, message, context)
+		r.parentLogger.storeLog(level, message, context, caller)
 		return
 	}
 	interfaceContext := make(map[string]interface{})
@@ -181,16 +339,48 @@ func (r *TestLogger) storeLog(level logging.Level, message string, context map[s
 		level:   level,
 		message: message,
 		context: interfaceContext,
+		caller:  caller,
 	})
 	r.logStoreMu.Unlock()
 }
 
-func (r *TestLogger) log(level logging.Level, message string) {
-	r.storeLog(level, message, r.GetAllContexts())
+func (r *TestLogger) log(level logging.Level, message string, fields map[string]interface{}) {
+	if !r.Enabled(level) {
+		return
+	}
+	r.storeLog(level, message, helper.MergeContext(r.GetAllContexts(), fields), r.callerPC())
+}
+
+// callerPC возвращает место вызова публичного метода логгера. Вызывается из log.
+func (r *TestLogger) callerPC() uintptr {
+	if r.caller != 0 {
+		return r.caller
+	}
+	return helper.CallerPC(3 + r.callerSkip)
 }
 
+// SetCtx возвращает дочерний логгер с уровнем запроса из ctx, если он есть. r не изменяется.
 func (r *TestLogger) SetCtx(ctx context.Context) logging.Logger {
-	return r
+	child := r.Clone().(*TestLogger)
+	if level, ok := logging.LevelFromContext(ctx); ok {
+		child.level.SetOverride(level)
+	}
+	return child
+}
+
+// Sync сбрасывает outLogger. Сохраненные записи выводятся только через ShowStoredLogs.
+func (r *TestLogger) Sync() error {
+	if r.outLogger == nil {
+		return nil
+	}
+	return r.outLogger.Sync()
+}
+
+// exit сбрасывает вывод через Sync и вызывает ExitFunc.
+func (r *TestLogger) exit() {
+	_ = r.Sync()
+	r.exitFunc(1)
 }
 
 var _ logging.Logger = (*TestLogger)(nil)
+var _ logging.CallerSetter = (*TestLogger)(nil)
@@ -1,9 +1,15 @@
 package testlog
 
 import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
 	"github.com/vsysa/logging/logger/logruslog"
+	"runtime"
 	"testing"
 )
 
@@ -16,7 +22,7 @@ func TestTestLogger_AddAndDeleteContext(t *testing.T) {
 	logger.AddContexts(map[string]interface{}{"key3": 3.0, "key4": true})
 
 	// Verify all contexts are added
-	expectedContexts := map[string]interface{}{"key1": "value1", "key2": "2", "key3": "3", "key4": "true"}
+	expectedContexts := map[string]interface{}{"key1": "value1", "key2": 2, "key3": 3.0, "key4": true}
 	assert.Equal(t, expectedContexts, logger.GetAllContexts(), "Contexts should match expected")
 
 	// Test DeleteContext
@@ -44,3 +50,164 @@ func TestTestLogger_Clone(t *testing.T) {
 	clonedLogger.AddContext("newKey", "newValue")
 	assert.NotEqual(t, originalLogger.context, clonedLogger.context, "Original logger's context should remain unchanged")
 }
+
+func TestTestLogger_KeyValue(t *testing.T) {
+	logger := NewTestLogger(logruslog.NewLogrusLogger())
+	logger.AddContext("key", "value")
+
+	logger.Infow("100% processed", "count", 3, "ok", true)
+	logger.Info("%d%% processed", 50)
+
+	require.Len(t, logger.logStore, 2)
+	assert.Equal(t, "100% processed", logger.logStore[0].message)
+	assert.Equal(t, map[string]interface{}{"key": "value", "count": 3, "ok": true}, logger.logStore[0].context)
+	assert.Equal(t, "50% processed", logger.logStore[1].message)
+	assert.Equal(t, map[string]interface{}{"key": "value"}, logger.GetAllContexts(), "Per-call fields should not change logger context")
+}
+
+func TestTestLogger_Fatal(t *testing.T) {
+	logger := NewTestLogger(logruslog.NewLogrusLogger())
+	exitCode := 0
+	logger.SetExitFunc(func(code int) { exitCode = code })
+
+	logger.Fatal("fatal")
+
+	assert.Equal(t, 1, exitCode)
+	require.Len(t, logger.logStore, 1)
+	assert.Equal(t, logging.FatalLevel, logger.logStore[0].level)
+
+	// повтор сохраненной Fatal-записи не должен завершать процесс
+	logger.ShowStoredLogs()
+}
+
+// syncBuffer — приемник, который считает вызовы Sync.
+type syncBuffer struct {
+	bytes.Buffer
+	syncs int
+}
+
+func (b *syncBuffer) Sync() error {
+	b.syncs++
+	return nil
+}
+
+func TestTestLogger_FatalSyncs(t *testing.T) {
+	out := &syncBuffer{}
+	logger := NewTestLogger(logruslog.NewLogrusLoggerWithRoutes(logging.Routes{{Level: logging.TraceLevel, Writer: out}}))
+	var syncsAtExit []int
+	logger.SetExitFunc(func(int) { syncsAtExit = append(syncsAtExit, out.syncs) })
+
+	logger.Fatal("fatal")
+	logger.Fatalw("fatalw")
+	logger.FatalCatch(errors.New("boom"), "fatal catch")
+	logger.Log(logging.FatalLevel, "log")
+	logger.Logw(logging.FatalLevel, "logw")
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, syncsAtExit, "Output should be synced before every exit")
+}
+
+func TestTestLogger_FatalDoesNotExitByDefault(t *testing.T) {
+	logger := NewTestLogger(nil)
+
+	logger.Fatal("fatal")
+	logger.Fatalw("fatalw")
+	logger.FatalCatch(errors.New("boom"), "fatal catch")
+	logger.Log(logging.FatalLevel, "log")
+
+	assert.Len(t, logger.logStore, 4, "Fatal entries should only be stored")
+}
+
+func TestTestLogger_Level(t *testing.T) {
+	logger := NewTestLogger(logruslog.NewLogrusLogger())
+	assert.Equal(t, logging.TraceLevel, logger.GetLevel(), "TestLogger should store everything by default")
+
+	logger.SetLevel(logging.InfoLevel)
+	assert.False(t, logger.Enabled(logging.DebugLevel))
+
+	logger.Debug("dropped")
+	logger.Clone().Info("stored")
+	require.Len(t, logger.logStore, 1)
+	assert.Equal(t, "stored", logger.logStore[0].message)
+
+	logger.ResetLevel()
+	assert.Equal(t, logging.TraceLevel, logger.GetLevel())
+}
+
+func TestTestLogger_CustomLevel(t *testing.T) {
+	notice := logging.MustRegisterLevel("notice", 14, logging.Green)
+	logger := NewTestLogger(logruslog.NewLogrusLogger())
+	logger.SetLevel(logging.InfoLevel)
+
+	logger.Logw(notice, "custom", "key", "value")
+	logger.Log(logging.Level(15), "unregistered")
+
+	require.Len(t, logger.logStore, 2)
+	assert.Equal(t, notice, logger.logStore[0].level)
+	assert.Equal(t, map[string]interface{}{"key": "value"}, logger.logStore[0].context)
+	assert.Equal(t, logging.WarnLevel, logger.logStore[1].level, "Unregistered level should be reported as a warning")
+
+	logger.ShowStoredLogs()
+}
+
+func TestTestLogger_ErrorCatch(t *testing.T) {
+	logger := NewTestLogger(logruslog.NewLogrusLogger())
+	err := fmt.Errorf("query users: %w", sql.ErrNoRows)
+
+	logger.ErrorCatch(err, "request %d failed", 7)
+	logger.ErrorCatch(nil, "no error")
+
+	require.Len(t, logger.logStore, 2)
+	assert.Equal(t, "request 7 failed", logger.logStore[0].message, "Error text should not be appended to the message")
+	assert.Equal(t, map[string]interface{}{
+		logging.ErrorMessageKey: "query users: sql: no rows in result set",
+		logging.ErrorTypeKey:    "*fmt.wrapError",
+		logging.ErrorCausesKey: []interface{}{
+			map[string]interface{}{logging.ErrorMessageKey: "sql: no rows in result set", logging.ErrorTypeKey: "*errors.errorString"},
+		},
+	}, logger.logStore[0].context[logging.ErrorKey])
+	assert.Contains(t, logger.logStore[0].context[logging.StacktraceKey], "testlog.TestTestLogger_ErrorCatch")
+	assert.NotContains(t, logger.logStore[1].context, logging.ErrorKey)
+}
+
+type tenantError struct {
+	tenantID string
+}
+
+func (e *tenantError) Error() string { return "tenant suspended" }
+
+func (e *tenantError) LogFields() map[string]any { return map[string]any{"tenant_id": e.tenantID} }
+
+func TestTestLogger_ErrorCatchLogFields(t *testing.T) {
+	logger := NewTestLogger(logruslog.NewLogrusLogger())
+	logger.AddContext("tenant_id", "from-context")
+
+	logger.ErrorCatch(fmt.Errorf("charge: %w", &tenantError{tenantID: "t-42"}), "payment failed")
+
+	require.Len(t, logger.logStore, 1)
+	assert.Equal(t, "t-42", logger.logStore[0].context["tenant_id"], "Error fields should be merged into the record")
+	assert.Equal(t, "from-context", logger.GetAllContexts()["tenant_id"], "Logger context should not change")
+}
+
+func TestTestLogger_ShowStoredLogsCaller(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewTestLogger(logruslog.NewLogrusLoggerWithRoutes(logging.Routes{{Level: logging.TraceLevel, Writer: buf}}))
+
+	_, file, line, _ := runtime.Caller(0)
+	logger.With("key", "value").Warn("stored")
+	logger.ShowStoredLogs()
+
+	assert.Contains(t, buf.String(), fmt.Sprintf("%s:%d", file, line+1), "Replay should keep the original caller")
+	assert.Contains(t, buf.String(), "testlog.TestTestLogger_ShowStoredLogsCaller")
+}
+
+func TestTestLogger_ShowStoredLogsTrace(t *testing.T) {
+	buf := &bytes.Buffer{}
+	out := logruslog.NewLogrusLoggerWithRoutes(logging.Routes{{Level: logging.TraceLevel, Writer: buf}})
+	out.SetLevel(logging.TraceLevel)
+	logger := NewTestLogger(out)
+
+	logger.Trace("trace record")
+	logger.ShowStoredLogs()
+
+	assert.Contains(t, buf.String(), "trace record", "Trace records should be replayed")
+}
@@ -0,0 +1,128 @@
+package zaplog
+
+import (
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/internal/helper"
+	"go.uber.org/zap/zapcore"
+	"strings"
+	"sync"
+)
+
+// TraceLevel — уровень zap ниже Debug. В zap его нет, поэтому ZapLogger регистрирует
+// собственный; стандартные энкодеры уровней zap выводят его как "LEVEL(-2)".
+const TraceLevel = zapcore.DebugLevel - 1
+
+// firstCustomLevel — первое значение zapcore.Level для пользовательских уровней logging;
+// следующие выделяются вниз от него. Значения ниже TraceLevel не пересекаются с уровнями
+// zap, и zap не считает такие записи серьезными: ioCore вызывает Sync после каждой записи
+// строже Error, а хуки часто сравнивают уровень с ErrorLevel. Порог и маршрут записи
+// определяются по ближайшему стандартному уровню (см. standardZapLevel).
+const firstCustomLevel = TraceLevel - 1
+
+var (
+	customMu     sync.RWMutex
+	customLevels = make(map[logging.Level]zapcore.Level)
+	customNames  = make(map[zapcore.Level]logging.Level)
+)
+
+// CustomLevel возвращает zapcore.Level, под которым ZapLogger пишет зарегистрированный
+// пользовательский уровень logging (см. logging.RegisterLevel). Значение выделяется при
+// первом обращении; энкодеры уровней этого пакета выводят его имя и цвет, а стандартные
+// энкодеры zap — "LEVEL(N)". Для стандартных и незарегистрированных уровней ok = false.
+func CustomLevel(level logging.Level) (zapcore.Level, bool) {
+	if _, ok := levelMap[level]; ok || !level.Registered() {
+		return 0, false
+	}
+
+	customMu.RLock()
+	zapLevel, ok := customLevels[level]
+	customMu.RUnlock()
+	if ok {
+		return zapLevel, true
+	}
+
+	customMu.Lock()
+	defer customMu.Unlock()
+	if zapLevel, ok := customLevels[level]; ok {
+		return zapLevel, true
+	}
+	next := firstCustomLevel - zapcore.Level(len(customLevels))
+	if next > firstCustomLevel {
+		return 0, false // int8 исчерпан
+	}
+	customLevels[level] = next
+	customNames[next] = level
+	return next, true
+}
+
+func customLevelOf(l zapcore.Level) (logging.Level, bool) {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	level, ok := customNames[l]
+	return level, ok
+}
+
+// toZapLevel переводит стандартный или пользовательский уровень logging в zapcore.Level.
+func toZapLevel(level logging.Level) (zapcore.Level, bool) {
+	if zapLevel, ok := levelMap[level]; ok {
+		return zapLevel, true
+	}
+	return CustomLevel(level)
+}
+
+// standardZapLevel возвращает для пользовательского уровня ближайший стандартный уровень zap,
+// по которому проверяется порог, а остальные уровни возвращает как есть.
+func standardZapLevel(l zapcore.Level) zapcore.Level {
+	if level, ok := customLevelOf(l); ok {
+		zapLevel, _ := helper.BackendLevel(levelMap, level)
+		return zapLevel
+	}
+	return l
+}
+
+// levelName возвращает имя и цвет TraceLevel и пользовательских уровней.
+func levelName(l zapcore.Level) (string, logging.Color, bool) {
+	if l == TraceLevel {
+		return logging.TraceLevel.String(), logging.TraceLevel.Color(), true
+	}
+	if level, ok := customLevelOf(l); ok {
+		return level.String(), level.Color(), true
+	}
+	return "", logging.NoColor, false
+}
+
+// LowercaseLevelEncoder — zapcore.LowercaseLevelEncoder с поддержкой TraceLevel и пользовательских уровней.
+func LowercaseLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if name, _, ok := levelName(l); ok {
+		enc.AppendString(name)
+		return
+	}
+	zapcore.LowercaseLevelEncoder(l, enc)
+}
+
+// LowercaseColorLevelEncoder — zapcore.LowercaseColorLevelEncoder с поддержкой TraceLevel и пользовательских уровней.
+func LowercaseColorLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if name, color, ok := levelName(l); ok {
+		enc.AppendString(color.Wrap(name))
+		return
+	}
+	zapcore.LowercaseColorLevelEncoder(l, enc)
+}
+
+// CapitalLevelEncoder — zapcore.CapitalLevelEncoder с поддержкой TraceLevel и пользовательских уровней.
+func CapitalLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if name, _, ok := levelName(l); ok {
+		enc.AppendString(strings.ToUpper(name))
+		return
+	}
+	zapcore.CapitalLevelEncoder(l, enc)
+}
+
+// CapitalColorLevelEncoder — zapcore.CapitalColorLevelEncoder с поддержкой TraceLevel и пользовательских уровней.
+func CapitalColorLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if name, color, ok := levelName(l); ok {
+		enc.AppendString(color.Wrap(strings.ToUpper(name)))
+		return
+	}
+	zapcore.CapitalColorLevelEncoder(l, enc)
+}
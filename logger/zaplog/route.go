@@ -0,0 +1,71 @@
+package zaplog
+
+import (
+	"errors"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/internal/helper"
+	"go.uber.org/zap/zapcore"
+	"slices"
+)
+
+// routingCore выбирает приемник по уровню записи, а не по выведенным байтам.
+type routingCore struct {
+	zapcore.LevelEnabler
+	routes logging.Routes
+	cores  []zapcore.Core // по одному на маршрут из routes
+}
+
+// NewRoutingCore создает zapcore.Core, который пишет каждую запись через encoder в приемник
+// маршрута из routes, выбранного по уровню logging записи (см. logging.Routes). Пользовательские
+// уровни маршрутизируются по своему значению, а не по ближайшему стандартному.
+// enabler задает общий порог, например zap.AtomicLevel.
+func NewRoutingCore(encoder zapcore.Encoder, routes logging.Routes, enabler zapcore.LevelEnabler) zapcore.Core {
+	c := &routingCore{LevelEnabler: enabler, routes: slices.Clone(routes), cores: make([]zapcore.Core, len(routes))}
+	for i, r := range routes {
+		c.cores[i] = zapcore.NewCore(encoder.Clone(), zapcore.Lock(zapcore.AddSync(r.Writer)), enabler)
+	}
+	return c
+}
+
+func (c *routingCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *routingCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &routingCore{LevelEnabler: c.LevelEnabler, routes: c.routes, cores: make([]zapcore.Core, len(c.cores))}
+	for i, core := range c.cores {
+		clone.cores[i] = core.With(fields)
+	}
+	return clone
+}
+
+func (c *routingCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *routingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	i := c.routes.Index(toLoggingLevel(entry.Level))
+	if i < 0 {
+		return nil
+	}
+	return c.cores[i].Write(entry, fields)
+}
+
+func (c *routingCore) Sync() error {
+	var errs []error
+	for _, core := range c.cores {
+		errs = append(errs, core.Sync())
+	}
+	return errors.Join(errs...)
+}
+
+// toLoggingLevel переводит уровень zap, в том числе пользовательский, в уровень logging.
+func toLoggingLevel(l zapcore.Level) logging.Level {
+	if level, ok := customLevelOf(l); ok {
+		return level
+	}
+	return helper.LevelFromBackend(levelMap, l, true)
+}
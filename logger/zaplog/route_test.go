@@ -0,0 +1,42 @@
+package zaplog
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/vsysa/logging"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"testing"
+)
+
+func TestRoutingCore(t *testing.T) {
+	notice := logging.MustRegisterLevel("route-notice", 13, logging.Green)
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeLevel = LowercaseLevelEncoder
+	atomicLevel := zap.NewAtomicLevelAt(TraceLevel)
+	core := NewRoutingCore(zapcore.NewJSONEncoder(encoderConfig), logging.Routes{
+		{Level: logging.TraceLevel, Writer: out},
+		{Level: notice, Writer: errOut},
+	}, atomicLevel)
+	logger := NewZapLogger(zap.New(core).With(zap.String("service", "api")), atomicLevel)
+
+	logger.Trace("trace")
+	logger.Info("info")
+	logger.Log(notice, "notice")
+	logger.Error("error")
+
+	assert.Contains(t, out.String(), `"msg":"trace"`)
+	assert.Contains(t, out.String(), `"msg":"info"`)
+	assert.Contains(t, errOut.String(), `"level":"route-notice"`)
+	assert.Contains(t, errOut.String(), `"msg":"notice"`, "Custom level should be routed by its own value")
+	assert.Contains(t, errOut.String(), `"msg":"error"`)
+	assert.NotContains(t, out.String(), `"msg":"notice"`)
+	assert.Contains(t, errOut.String(), `"service":"api"`, "Fields added with With should reach every route")
+
+	out.Reset()
+	atomicLevel.SetLevel(zap.WarnLevel)
+	logger.Info("hidden")
+	assert.Empty(t, out.String())
+	assert.Equal(t, zap.WarnLevel, zapcore.LevelOf(core))
+}
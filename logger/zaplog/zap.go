@@ -2,44 +2,83 @@ package zaplog
 
 import (
 	"context"
-	"fmt"
 	"github.com/vsysa/logging"
 	"github.com/vsysa/logging/internal/helper"
-	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"os"
 	"sync"
 )
 
 var levelMap = map[logging.Level]zapcore.Level{
-	logging.TraceLevel: zapcore.DebugLevel, // Zap не имеет Trace уровня, используем Debug
+	logging.TraceLevel: TraceLevel,
 	logging.DebugLevel: zapcore.DebugLevel,
 	logging.InfoLevel:  zapcore.InfoLevel,
 	logging.WarnLevel:  zapcore.WarnLevel,
 	logging.ErrorLevel: zapcore.ErrorLevel,
+	logging.PanicLevel: zapcore.PanicLevel,
 	logging.FatalLevel: zapcore.FatalLevel,
 }
 
 type ZapLogger struct {
-	zapLogger   *zap.Logger
-	contextMu   sync.RWMutex
-	context     map[string]interface{}
-	atomicLevel zap.AtomicLevel
+	zapLogger *zap.Logger
+	contextMu sync.RWMutex
+	context   map[string]interface{}
+	name      string
+	// floor хранит уровень бэкенда и опускает atomicLevel для клонов с более низким
+	// собственным уровнем (см. helper.LevelFloor)
+	floor    *helper.LevelFloor[zapcore.Level]
+	level    *helper.LevelNode[zapcore.Level]
+	exitFunc logging.ExitFunc
+	// callerSkip — дополнительные кадры над публичным методом (см. WithCallerSkip),
+	// caller — заданное через WithCaller место вызова.
+	callerSkip int
+	caller     uintptr
 }
 
+// skipHook отключает os.Exit и panic внутри zap: выход через ExitFunc и panic
+// выполняет сам ZapLogger.
+type skipHook struct{}
+
+func (skipHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
+// NewZapLogger оборачивает zapLogger, cores которого проверяют atomicLevel. Клон с уровнем
+// ниже уровня бэкенда опускает atomicLevel до своего уровня на время проверки своей записи,
+// а записи остальных логгеров отсекает сам ZapLogger, поэтому уровень следует менять через SetLevel, а не через
+// atomicLevel напрямую. Собственные уровни cores, например в zapcore.NewTee, действуют всегда.
 func NewZapLogger(zapLogger *zap.Logger, atomicLevel zap.AtomicLevel) *ZapLogger {
 	if zapLogger == nil {
+		if atomicLevel == (zap.AtomicLevel{}) {
+			atomicLevel = zap.NewAtomicLevelAt(zap.InfoLevel)
+		}
+		config := zap.NewProductionConfig() // Можно использовать zap.NewProduction() или любую другую конфигурацию
+		config.Level = atomicLevel
+		config.EncoderConfig.EncodeLevel = LowercaseLevelEncoder
+		config.EncoderConfig.FunctionKey = "func"
+
 		var err error
-		zapLogger, err = zap.NewProduction() // Можно использовать zap.NewProduction() или любую другую конфигурацию
+		zapLogger, err = config.Build()
 		if err != nil {
 			panic("Failed to create zap logger: " + err.Error())
 		}
 	}
 
+	if atomicLevel == (zap.AtomicLevel{}) {
+		atomicLevel = zap.NewAtomicLevelAt(zapcore.LevelOf(zapLogger.Core()))
+	}
+	// место вызова определяет сам ZapLogger: глубина вызова zap внутри обертки не зависит
+	// от zap.AddCallerSkip, с которым создан zapLogger. Стек пишется в поле logging.StacktraceKey
+	// (см. helper.ErrorFields), поэтому собственный стек zap отключен: иначе ключ повторился бы
+	zapLogger = zapLogger.WithOptions(zap.WithFatalHook(skipHook{}), zap.WithPanicHook(skipHook{}), zap.WithCaller(false),
+		zap.AddStacktrace(zapcore.InvalidLevel))
+
+	floor := helper.NewLevelFloor(atomicLevel.Level, atomicLevel.SetLevel)
 	newLogger := &ZapLogger{
-		zapLogger:   zapLogger,
-		context:     make(map[string]interface{}),
-		atomicLevel: atomicLevel,
+		zapLogger: zapLogger,
+		context:   make(map[string]interface{}),
+		floor:     floor,
+		level:     helper.NewLevelNode(floor.Level),
+		exitFunc:  os.Exit,
 	}
 
 	return newLogger
@@ -50,7 +89,7 @@ func NewZapLogger(zapLogger *zap.Logger, atomicLevel zap.AtomicLevel) *ZapLogger
 func (r *ZapLogger) AddContext(key string, value interface{}) logging.Logger {
 	r.contextMu.Lock()
 	defer r.contextMu.Unlock()
-	r.context[key] = fmt.Sprintf("%v", value)
+	r.context[key] = helper.NormalizeValue(value)
 	return r
 }
 
@@ -58,7 +97,7 @@ func (r *ZapLogger) AddContexts(contexts map[string]interface{}) logging.Logger
 	r.contextMu.Lock()
 	defer r.contextMu.Unlock()
 	for key, value := range contexts {
-		r.context[key] = fmt.Sprintf("%v", value)
+		r.context[key] = helper.NormalizeValue(value)
 	}
 	return r
 }
@@ -82,90 +121,255 @@ func (r *ZapLogger) GetAllContexts() map[string]interface{} {
 
 // LOGGING
 
+// SetLevel меняет уровень бэкенда для корневого логгера и собственный уровень для клона.
 func (r *ZapLogger) SetLevel(level logging.Level) {
-	if zapLevel, ok := levelMap[level]; ok {
-		r.atomicLevel.SetLevel(zapLevel)
-	} else {
+	if zapLevel, ok := levelMap[level]; !ok {
 		r.Warn("Invalid logging level: %v", level)
 		return
+	} else if r.level.IsRoot() {
+		r.floor.SetLevel(zapLevel)
+	} else {
+		r.level.SetOverride(zapLevel)
 	}
 }
 
+func (r *ZapLogger) ResetLevel() {
+	r.level.ClearOverride()
+}
+
+// HasLevelOverride сообщает, задан ли логгеру собственный уровень (см. ResetLevel).
+func (r *ZapLogger) HasLevelOverride() bool {
+	return r.level.HasOverride()
+}
+
+// GetLevel возвращает действующий уровень логгера с учетом наследования.
+func (r *ZapLogger) GetLevel() logging.Level {
+	return helper.LevelFromBackend(levelMap, r.level.Level(), true)
+}
+
+func (r *ZapLogger) Enabled(level logging.Level) bool {
+	zapLevel, ok := helper.BackendLevel(levelMap, level)
+	return ok && zapLevel >= r.level.Level()
+}
+
 func (r *ZapLogger) Trace(message string, a ...any) {
-	r.log(zap.DebugLevel, fmt.Sprintf(message, a...))
+	r.log(TraceLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *ZapLogger) Debug(message string, a ...any) {
-	r.log(zap.DebugLevel, fmt.Sprintf(message, a...))
+	r.log(zap.DebugLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *ZapLogger) Info(message string, a ...any) {
-	r.log(zap.InfoLevel, fmt.Sprintf(message, a...))
+	r.log(zap.InfoLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *ZapLogger) Warn(message string, a ...any) {
-	r.log(zap.WarnLevel, fmt.Sprintf(message, a...))
+	r.log(zap.WarnLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *ZapLogger) Error(message string, a ...any) {
-	r.log(zap.ErrorLevel, fmt.Sprintf(message, a...))
+	r.log(zap.ErrorLevel, helper.FormatMessage(message, a), nil)
 }
 
 func (r *ZapLogger) Fatal(message string, a ...any) {
-	r.log(zap.FatalLevel, fmt.Sprintf(message, a...))
+	r.log(zap.FatalLevel, helper.FormatMessage(message, a), nil)
+	r.exit()
 }
 
 func (r *ZapLogger) ErrorCatch(err error, message string, a ...any) {
-	msg := fmt.Sprintf(message, a...)
-	if err != nil {
-		msg += ": " + err.Error()
+	if r.Enabled(logging.ErrorLevel) {
+		r.log(zap.ErrorLevel, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.ErrorLevel, r.callerSkip))
 	}
-	r.log(zap.ErrorLevel, msg)
 }
 
 func (r *ZapLogger) FatalCatch(err error, message string, a ...any) {
-	msg := fmt.Sprintf(message, a...)
+	if r.Enabled(logging.FatalLevel) {
+		r.log(zap.FatalLevel, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.FatalLevel, r.callerSkip))
+	}
+	r.exit()
+}
+
+func (r *ZapLogger) Panic(message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	r.log(zapcore.PanicLevel, msg, nil)
+	panic(msg)
+}
+
+func (r *ZapLogger) PanicCatch(err error, message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	if r.Enabled(logging.PanicLevel) {
+		r.log(zapcore.PanicLevel, msg, helper.ErrorFields(err, logging.PanicLevel, r.callerSkip))
+	}
 	if err != nil {
-		msg += ": " + err.Error()
+		panic(err)
+	}
+	panic(msg)
+}
+
+func (r *ZapLogger) Tracew(message string, keysAndValues ...any) {
+	r.log(TraceLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZapLogger) Debugw(message string, keysAndValues ...any) {
+	r.log(zap.DebugLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZapLogger) Infow(message string, keysAndValues ...any) {
+	r.log(zap.InfoLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZapLogger) Warnw(message string, keysAndValues ...any) {
+	r.log(zap.WarnLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZapLogger) Errorw(message string, keysAndValues ...any) {
+	r.log(zap.ErrorLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZapLogger) Fatalw(message string, keysAndValues ...any) {
+	r.log(zap.FatalLevel, message, helper.KeysAndValues(keysAndValues))
+	r.exit()
+}
+
+// Log пишет запись на уровне level. Пользовательский уровень выводится под своим
+// именем (см. CustomLevel), а в cores проверяется как ближайший стандартный.
+func (r *ZapLogger) Log(level logging.Level, message string, a ...any) {
+	zapLevel, ok := toZapLevel(level)
+	if !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(zapLevel, helper.FormatMessage(message, a), nil)
+	if level == logging.FatalLevel {
+		r.exit()
+	}
+}
+
+func (r *ZapLogger) Logw(level logging.Level, message string, keysAndValues ...any) {
+	zapLevel, ok := toZapLevel(level)
+	if !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(zapLevel, message, helper.KeysAndValues(keysAndValues))
+	if level == logging.FatalLevel {
+		r.exit()
 	}
-	r.log(zap.FatalLevel, msg)
 }
 
 // BASE
 
 func (r *ZapLogger) Clone() logging.Logger {
+	return r.derive(r.GetAllContexts())
+}
+
+func (r *ZapLogger) With(keysAndValues ...any) logging.Logger {
+	return r.derive(helper.MergeContext(r.GetAllContexts(), helper.KeysAndValues(keysAndValues)))
+}
+
+func (r *ZapLogger) Named(name string) logging.Logger {
+	fullName := logging.JoinName(r.name, name)
+	child := r.derive(helper.MergeContext(r.GetAllContexts(), map[string]interface{}{logging.LoggerNameKey: fullName}))
+	child.name = fullName
+	child.level = r.level.ChildWithLookup(helper.NamedLevelLookup(fullName, levelMap))
+	return child
+}
+
+// derive создает дочерний логгер с общим бэкендом, наследующий уровень r.
+func (r *ZapLogger) derive(context map[string]interface{}) *ZapLogger {
 	return &ZapLogger{
-		zapLogger: r.zapLogger,
-		context:   helper.CopyMapContext(r.context),
+		zapLogger:  r.zapLogger,
+		context:    context,
+		name:       r.name,
+		floor:      r.floor,
+		level:      r.level.Child(),
+		exitFunc:   r.exitFunc,
+		callerSkip: r.callerSkip,
+		caller:     r.caller,
 	}
 }
 
-func (r *ZapLogger) SetCtx(ctx context.Context) logging.Logger {
-	span := trace.SpanFromContext(ctx)
-	if span.SpanContext().IsValid() {
-		traceID := span.SpanContext().TraceID().String()
-		spanID := span.SpanContext().SpanID().String()
+func (r *ZapLogger) WithCallerSkip(skip int) logging.Logger {
+	child := r.derive(r.GetAllContexts())
+	child.callerSkip += skip
+	return child
+}
 
-		r.AddContexts(map[string]interface{}{
-			"traceID": traceID,
-			"spanID":  spanID,
-		})
+func (r *ZapLogger) WithCaller(pc uintptr) logging.Logger {
+	child := r.derive(r.GetAllContexts())
+	child.caller = pc
+	return child
+}
+
+// SetCtx возвращает производный логгер, привязанный к ctx: с traceID и spanID активного
+// спана и уровнем запроса, если он есть в ctx (см. logging.LevelFromContext). r не изменяется.
+func (r *ZapLogger) SetCtx(ctx context.Context) logging.Logger {
+	child := r.derive(helper.MergeContext(r.GetAllContexts(), helper.SpanFields(ctx)))
+	if level, ok := helper.ContextLevel(ctx, levelMap); ok {
+		child.level.SetOverride(level)
 	}
+	return child
+}
 
-	return r
+func (r *ZapLogger) SetExitFunc(exitFunc logging.ExitFunc) {
+	r.exitFunc = exitFunc
+}
+
+// Sync сбрасывает буферы cores zap.
+func (r *ZapLogger) Sync() error {
+	return helper.SyncError(r.zapLogger.Sync())
+}
+
+func (r *ZapLogger) exit() {
+	_ = r.Sync()
+	r.exitFunc(1)
 }
 
-func (r *ZapLogger) log(level zapcore.Level, message string) {
-	fields := r.getZapFields()
-	r.zapLogger.With(fields...).Check(level, message).Write()
+func (r *ZapLogger) log(level zapcore.Level, message string, fields map[string]interface{}) {
+	// пользовательский уровень проверяется и направляется в cores как ближайший стандартный
+	checkLevel := standardZapLevel(level)
+	if checkLevel < r.level.Level() {
+		return
+	}
+	// клон с уровнем ниже уровня бэкенда опускает atomicLevel на время проверки cores
+	restore := r.floor.Lower(checkLevel)
+	ce := r.zapLogger.Check(checkLevel, message)
+	restore()
+	if ce != nil {
+		ce.Entry.Level = level
+		ce.Entry.Caller = r.entryCaller()
+		ce.Write(r.getZapFields(fields)...)
+	}
 }
 
-func (r *ZapLogger) getZapFields() []zap.Field {
-	fields := make([]zap.Field, 0, len(r.context))
-	for key, value := range r.GetAllContexts() {
+// entryCaller возвращает место вызова публичного метода логгера. Вызывается из log.
+func (r *ZapLogger) entryCaller() zapcore.EntryCaller {
+	pc := r.caller
+	if pc == 0 {
+		pc = helper.CallerPC(3 + r.callerSkip)
+	}
+	if pc == 0 {
+		return zapcore.EntryCaller{}
+	}
+	frame := helper.CallerFrame(pc)
+	return zapcore.EntryCaller{
+		Defined:  true,
+		PC:       frame.PC,
+		File:     frame.File,
+		Line:     frame.Line,
+		Function: frame.Function,
+	}
+}
+
+func (r *ZapLogger) getZapFields(extra map[string]interface{}) []zap.Field {
+	contexts := helper.MergeContext(r.GetAllContexts(), extra)
+	fields := make([]zap.Field, 0, len(contexts))
+	for key, value := range contexts {
 		fields = append(fields, zap.Any(key, value))
 	}
 	return fields
 }
 
 var _ logging.Logger = &ZapLogger{}
+var _ logging.CallerSetter = &ZapLogger{}
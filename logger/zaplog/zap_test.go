@@ -1,17 +1,25 @@
 package zaplog
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vsysa/logging"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 	"os"
+	"strings"
 	"testing"
 )
 
 func getZapLogger() (*ZapLogger, zap.AtomicLevel) {
 	encoderConfig := zap.NewDevelopmentEncoderConfig()
-	encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // CapitalColorLevelEncoder добавляет цвет в зависимости от уровня
+	encoderConfig.EncodeLevel = CapitalColorLevelEncoder // CapitalColorLevelEncoder добавляет цвет в зависимости от уровня
 	atomicLevel := zap.NewAtomicLevelAt(zap.DebugLevel)
 
 	consoleCore := zapcore.NewCore(
@@ -20,7 +28,7 @@ func getZapLogger() (*ZapLogger, zap.AtomicLevel) {
 		atomicLevel,
 	)
 
-	return NewZapLogger(zap.New(consoleCore, zap.AddCaller(), zap.AddCallerSkip(2)), atomicLevel), atomicLevel
+	return NewZapLogger(zap.New(consoleCore), atomicLevel), atomicLevel
 }
 
 func TestBaseLogger_SetLevel(t *testing.T) {
@@ -38,3 +46,279 @@ func TestBaseLogger_SetLevel(t *testing.T) {
 //	logger.Warn("This is warn log")
 //	logger.Error("This is error log")
 //}
+
+func TestZapLogger_TypedContext(t *testing.T) {
+	buf := &bytes.Buffer{}
+	atomicLevel := zap.NewAtomicLevelAt(zap.DebugLevel)
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(buf), atomicLevel)
+	logger := NewZapLogger(zap.New(core), atomicLevel)
+
+	logger.AddContexts(map[string]interface{}{
+		"count":   3,
+		"enabled": true,
+		"tags":    []string{"a", "b"},
+	})
+	logger.Info("typed")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, float64(3), entry["count"])
+	assert.Equal(t, true, entry["enabled"])
+	assert.Equal(t, []interface{}{"a", "b"}, entry["tags"])
+}
+
+func TestZapLogger_KeyValue(t *testing.T) {
+	atomicLevel := zap.NewAtomicLevelAt(zap.DebugLevel)
+	core, logs := observer.New(atomicLevel)
+	logger := NewZapLogger(zap.New(core), atomicLevel)
+	logger.AddContext("key", "value")
+
+	logger.Warnw("disk at 90%", "free", 10, "mount", "/data")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, "disk at 90%", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"key": "value", "free": int64(10), "mount": "/data"}, entries[0].ContextMap())
+	assert.Equal(t, map[string]interface{}{"key": "value"}, logger.GetAllContexts(), "Per-call fields should not change logger context")
+}
+
+func TestZapLogger_CloneLevelHierarchy(t *testing.T) {
+	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core, logs := observer.New(atomicLevel)
+	parent := NewZapLogger(zap.New(core), atomicLevel)
+	child := parent.Clone()
+	sibling := parent.Clone()
+
+	child.SetLevel(logging.DebugLevel)
+	assert.Equal(t, zapcore.InfoLevel, atomicLevel.Level(), "Child override should not change backend level")
+
+	parent.Debug("parent debug")
+	sibling.Debug("sibling debug")
+	child.Debug("child debug")
+	require.Len(t, logs.TakeAll(), 1, "Only the overriding child should emit Debug")
+	assert.Equal(t, logging.InfoLevel, parent.GetLevel(), "Lowering for the child should not change the backend level")
+
+	parent.SetLevel(logging.ErrorLevel)
+	sibling.Warn("sibling warn")
+	child.Warn("child warn")
+	require.Len(t, logs.TakeAll(), 1, "Sibling should follow parent level live")
+
+	child.ResetLevel()
+	child.Warn("child warn after reset")
+	assert.Empty(t, logs.TakeAll(), "Child should inherit parent level after reset")
+}
+
+func TestZapLogger_CloneLevelKeepsCoreLevels(t *testing.T) {
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core := zapcore.NewTee(
+		zapcore.NewCore(encoder, zapcore.AddSync(out), atomicLevel),
+		zapcore.NewCore(encoder, zapcore.AddSync(errOut), zap.ErrorLevel),
+	)
+	logger := NewZapLogger(zap.New(core), atomicLevel)
+
+	child := logger.Clone()
+	child.SetLevel(logging.DebugLevel)
+	child.Debug("child debug")
+	logger.Debug("parent debug")
+
+	assert.Contains(t, out.String(), "child debug")
+	assert.NotContains(t, out.String(), "parent debug")
+	assert.Empty(t, errOut.String(), "Error-only core should keep its own level")
+}
+
+func TestZapLogger_RequestLevelDoesNotLeak(t *testing.T) {
+	out := &bytes.Buffer{}
+	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(out), atomicLevel)
+	raw := zap.New(core)
+	logger := NewZapLogger(raw, atomicLevel)
+
+	requestLogger := logger.SetCtx(logging.ContextWithLevel(context.Background(), logging.TraceLevel))
+	requestLogger.Trace("request trace")
+	assert.Contains(t, out.String(), "request trace")
+	assert.Equal(t, zap.InfoLevel, atomicLevel.Level(), "Shared level should be restored after the entry")
+
+	raw.Debug("raw debug")
+	assert.NotContains(t, out.String(), "raw debug", "Loggers sharing the core should not see the lowered level")
+}
+
+func TestZapLogger_NamedLevels(t *testing.T) {
+	t.Cleanup(logging.NamedLevels.Reset)
+
+	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core, logs := observer.New(atomicLevel)
+	logger := NewZapLogger(zap.New(core), atomicLevel)
+
+	db := logger.Named("db")
+	pool := db.Named("pool")
+	http := logger.Named("http")
+
+	logging.NamedLevels.Set("db", logging.DebugLevel)
+	logging.NamedLevels.Set("http", logging.WarnLevel)
+
+	logger.Debug("root debug")
+	pool.Debug("pool debug")
+	http.Info("http info")
+	http.Warn("http warn")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "pool debug", entries[0].Message)
+	assert.Equal(t, "db.pool", entries[0].ContextMap()[logging.LoggerNameKey])
+	assert.Equal(t, "http warn", entries[1].Message)
+}
+
+func TestZapLogger_TraceLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeLevel = CapitalLevelEncoder
+	atomicLevel := zap.NewAtomicLevelAt(zap.DebugLevel)
+	logger := NewZapLogger(zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(buf), atomicLevel)), atomicLevel)
+
+	logger.Trace("hidden")
+	assert.Empty(t, buf.String(), "Trace should be filtered at Debug level")
+
+	logger.SetLevel(logging.TraceLevel)
+	assert.Equal(t, TraceLevel, atomicLevel.Level())
+	logger.Trace("visible")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "TRACE", entry["level"])
+	assert.Equal(t, "visible", entry["msg"])
+}
+
+func TestLevelEncoders(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder zapcore.LevelEncoder
+		want    string
+	}{
+		{"Lowercase", LowercaseLevelEncoder, "trace"},
+		{"LowercaseColor", LowercaseColorLevelEncoder, "\x1b[36mtrace\x1b[0m"},
+		{"Capital", CapitalLevelEncoder, "TRACE"},
+		{"CapitalColor", CapitalColorLevelEncoder, "\x1b[36mTRACE\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := &stringArrayEncoder{}
+			tt.encoder(TraceLevel, enc)
+			tt.encoder(zapcore.InfoLevel, enc)
+			require.Len(t, enc.values, 2)
+			assert.Equal(t, tt.want, enc.values[0])
+			assert.NotContains(t, enc.values[1], "LEVEL", "Standard levels should be delegated to zap")
+		})
+	}
+}
+
+type stringArrayEncoder struct {
+	zapcore.PrimitiveArrayEncoder
+	values []string
+}
+
+func (e *stringArrayEncoder) AppendString(v string) {
+	e.values = append(e.values, v)
+}
+
+func TestZapLogger_CustomLevel(t *testing.T) {
+	notice := logging.MustRegisterLevel("notice", 14, logging.Green)
+	buf := &bytes.Buffer{}
+	errBuf := &bytes.Buffer{}
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeLevel = LowercaseLevelEncoder
+	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core := zapcore.NewTee(
+		zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(buf), atomicLevel),
+		zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(errBuf), zap.ErrorLevel),
+	)
+	logger := NewZapLogger(zap.New(core), atomicLevel)
+
+	assert.True(t, logger.Enabled(notice))
+	logger.Logw(notice, "custom", "key", "value")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "notice", entry["level"])
+	assert.Equal(t, "custom", entry["msg"])
+	assert.Equal(t, "value", entry["key"])
+	assert.Empty(t, errBuf.String(), "Custom level should be routed like the nearest standard level")
+
+	buf.Reset()
+	logger.SetLevel(logging.WarnLevel)
+	assert.False(t, logger.Enabled(notice))
+	logger.Log(notice, "hidden")
+	assert.Empty(t, buf.String(), "Custom level should be filtered like the nearest standard level")
+
+	zapLevel, ok := CustomLevel(notice)
+	require.True(t, ok)
+	enc := &stringArrayEncoder{}
+	CapitalColorLevelEncoder(zapLevel, enc)
+	assert.Equal(t, []string{"\x1b[32mNOTICE\x1b[0m"}, enc.values)
+}
+
+// syncCounter — приемник, который считает вызовы Sync.
+type syncCounter struct {
+	bytes.Buffer
+	syncs int
+}
+
+func (w *syncCounter) Sync() error {
+	w.syncs++
+	return nil
+}
+
+func TestZapLogger_CustomLevelSync(t *testing.T) {
+	audit := logging.MustRegisterLevel("zap-audit", 21, logging.Red)
+	out := &syncCounter{}
+	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), out, atomicLevel)
+	logger := NewZapLogger(zap.New(core), atomicLevel)
+
+	for i := 0; i < 5; i++ {
+		logger.Log(audit, "audit")
+	}
+	assert.Equal(t, 5, strings.Count(out.String(), "\n"))
+	assert.Zero(t, out.syncs, "Custom levels should not be treated as more severe than Error by zap")
+
+	zapLevel, ok := CustomLevel(audit)
+	require.True(t, ok)
+	assert.Less(t, zapLevel, TraceLevel, "Custom levels should use values below TraceLevel")
+}
+
+func TestZapLogger_ErrorCatch(t *testing.T) {
+	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core, logs := observer.New(atomicLevel)
+	logger := NewZapLogger(zap.New(core), atomicLevel)
+
+	logger.ErrorCatch(fmt.Errorf("dial: %w", os.ErrDeadlineExceeded), "connect failed")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "connect failed", entries[0].Message)
+	errorField, ok := entries[0].ContextMap()[logging.ErrorKey].(map[string]interface{})
+	require.True(t, ok, "Error should be emitted as a structured field")
+	assert.Equal(t, "dial: i/o timeout", errorField[logging.ErrorMessageKey])
+	assert.Equal(t, "*fmt.wrapError", errorField[logging.ErrorTypeKey])
+	assert.Len(t, errorField[logging.ErrorCausesKey], 1)
+	assert.True(t, strings.HasPrefix(entries[0].ContextMap()[logging.StacktraceKey].(string), "github.com/vsysa/logging/logger/zaplog.TestZapLogger_ErrorCatch\n"),
+		"Stack should start at the ErrorCatch call site")
+}
+
+func TestZapLogger_SingleStacktrace(t *testing.T) {
+	buf := &bytes.Buffer{}
+	encoderConfig := zap.NewProductionEncoderConfig()
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(buf), zap.InfoLevel)
+	logger := NewZapLogger(zap.New(core, zap.AddStacktrace(zap.ErrorLevel)), zap.AtomicLevel{})
+
+	logger.ErrorCatch(errors.New("boom"), "failed")
+	logger.Error("plain")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, 1, strings.Count(lines[0], `"`+logging.StacktraceKey+`"`), "zap's own stacktrace should not duplicate the key")
+	assert.NotContains(t, lines[1], logging.StacktraceKey, "zap.AddStacktrace of the wrapped logger should be disabled")
+}
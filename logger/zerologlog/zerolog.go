@@ -0,0 +1,370 @@
+package zerologlog
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/internal/helper"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// FuncFieldName — поле с функцией места вызова. Файл и строка пишутся в
+// zerolog.CallerFieldName через zerolog.CallerMarshalFunc.
+const FuncFieldName = "func"
+
+// levelMap переводит уровни logging в уровни zerolog. zerolog.PanicLevel строже Fatal,
+// поэтому logging.PanicLevel переводится в zerolog.ErrorLevel, а ZerologLogger хранит
+// уровень в единицах logging.
+var levelMap = map[logging.Level]zerolog.Level{
+	logging.TraceLevel: zerolog.TraceLevel,
+	logging.DebugLevel: zerolog.DebugLevel,
+	logging.InfoLevel:  zerolog.InfoLevel,
+	logging.WarnLevel:  zerolog.WarnLevel,
+	logging.ErrorLevel: zerolog.ErrorLevel,
+	logging.PanicLevel: zerolog.ErrorLevel,
+	logging.FatalLevel: zerolog.FatalLevel,
+}
+
+// levels — уровни, которые можно задать ZerologLogger.
+var levels = helper.LevelKeys(levelMap)
+
+// AtomicLevel — потокобезопасный уровень, общий для логгеров одной фабрики.
+// zerolog.Logger хранит уровень по значению, поэтому изменить его на месте нельзя.
+type AtomicLevel struct {
+	level atomic.Int32
+}
+
+func NewAtomicLevel(level zerolog.Level) *AtomicLevel {
+	al := &AtomicLevel{}
+	al.SetLevel(level)
+	return al
+}
+
+func (r *AtomicLevel) Level() zerolog.Level {
+	return zerolog.Level(r.level.Load())
+}
+
+func (r *AtomicLevel) SetLevel(level zerolog.Level) {
+	r.level.Store(int32(level))
+}
+
+type ZerologLogger struct {
+	zerolog     *zerolog.Logger
+	contextMu   sync.RWMutex
+	context     map[string]interface{}
+	name        string
+	atomicLevel *AtomicLevel
+	rootLevel   *helper.AliasedLevel[zerolog.Level]
+	level       *helper.LevelNode[logging.Level]
+	exitFunc    logging.ExitFunc
+	// callerSkip — дополнительные кадры над публичным методом (см. WithCallerSkip),
+	// caller — заданное через WithCaller место вызова.
+	callerSkip int
+	caller     uintptr
+}
+
+// NewZerologLogger оборачивает zerologLogger. Его собственный уровень становится
+// начальным уровнем atomicLevel, если тот не задан, а сам логгер переводится на Trace:
+// уровень проверяет только ZerologLogger, иначе переопределения уровня у клонов
+// отбрасывались бы zerolog. zerolog.GlobalLevel по-прежнему действует (см. Enabled).
+func NewZerologLogger(zerologLogger *zerolog.Logger, atomicLevel *AtomicLevel) *ZerologLogger {
+	if zerologLogger == nil {
+		l := zerolog.New(os.Stdout).With().Timestamp().Logger()
+		zerologLogger = &l
+	}
+	if atomicLevel == nil {
+		atomicLevel = NewAtomicLevel(zerologLogger.GetLevel())
+	}
+	unfiltered := zerologLogger.Level(zerolog.TraceLevel)
+	rootLevel := helper.NewAliasedLevel(levelMap, true, atomicLevel.Level, atomicLevel.SetLevel)
+
+	return &ZerologLogger{
+		zerolog:     &unfiltered,
+		context:     make(map[string]interface{}),
+		atomicLevel: atomicLevel,
+		rootLevel:   rootLevel,
+		level:       helper.NewLevelNode(rootLevel.Level),
+		exitFunc:    os.Exit,
+	}
+}
+
+// CONTEXT
+
+func (r *ZerologLogger) AddContext(key string, value interface{}) logging.Logger {
+	r.contextMu.Lock()
+	defer r.contextMu.Unlock()
+	r.context[key] = helper.NormalizeValue(value)
+	return r
+}
+
+func (r *ZerologLogger) AddContexts(contexts map[string]interface{}) logging.Logger {
+	r.contextMu.Lock()
+	defer r.contextMu.Unlock()
+	for key, value := range contexts {
+		r.context[key] = helper.NormalizeValue(value)
+	}
+	return r
+}
+
+func (r *ZerologLogger) DeleteContext(key string) logging.Logger {
+	r.contextMu.Lock()
+	defer r.contextMu.Unlock()
+	delete(r.context, key)
+	return r
+}
+
+func (r *ZerologLogger) GetAllContexts() map[string]interface{} {
+	r.contextMu.RLock()
+	defer r.contextMu.RUnlock()
+	return helper.CopyMapContext(r.context)
+}
+
+// LOGGING
+
+// SetLevel меняет общий AtomicLevel для корневого логгера и собственный уровень для клона.
+func (r *ZerologLogger) SetLevel(level logging.Level) {
+	if _, ok := levelMap[level]; !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	} else if r.level.IsRoot() {
+		r.rootLevel.SetLevel(level)
+	} else {
+		r.level.SetOverride(level)
+	}
+}
+
+func (r *ZerologLogger) ResetLevel() {
+	r.level.ClearOverride()
+}
+
+// HasLevelOverride сообщает, задан ли логгеру собственный уровень (см. ResetLevel).
+func (r *ZerologLogger) HasLevelOverride() bool {
+	return r.level.HasOverride()
+}
+
+// GetLevel возвращает действующий уровень логгера с учетом наследования.
+func (r *ZerologLogger) GetLevel() logging.Level {
+	return r.level.Level()
+}
+
+// Enabled учитывает и zerolog.GlobalLevel: его zerolog проверяет при каждой записи.
+func (r *ZerologLogger) Enabled(level logging.Level) bool {
+	zerologLevel, ok := helper.BackendLevel(levelMap, level)
+	return ok && level >= r.level.Level() && zerologLevel >= zerolog.GlobalLevel()
+}
+
+func (r *ZerologLogger) Trace(message string, a ...any) {
+	r.log(logging.TraceLevel, helper.FormatMessage(message, a), nil)
+}
+
+func (r *ZerologLogger) Debug(message string, a ...any) {
+	r.log(logging.DebugLevel, helper.FormatMessage(message, a), nil)
+}
+
+func (r *ZerologLogger) Info(message string, a ...any) {
+	r.log(logging.InfoLevel, helper.FormatMessage(message, a), nil)
+}
+
+func (r *ZerologLogger) Warn(message string, a ...any) {
+	r.log(logging.WarnLevel, helper.FormatMessage(message, a), nil)
+}
+
+func (r *ZerologLogger) Error(message string, a ...any) {
+	r.log(logging.ErrorLevel, helper.FormatMessage(message, a), nil)
+}
+
+func (r *ZerologLogger) Fatal(message string, a ...any) {
+	r.log(logging.FatalLevel, helper.FormatMessage(message, a), nil)
+	r.exit()
+}
+
+func (r *ZerologLogger) ErrorCatch(err error, message string, a ...any) {
+	if r.Enabled(logging.ErrorLevel) {
+		r.log(logging.ErrorLevel, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.ErrorLevel, r.callerSkip))
+	}
+}
+
+func (r *ZerologLogger) FatalCatch(err error, message string, a ...any) {
+	if r.Enabled(logging.FatalLevel) {
+		r.log(logging.FatalLevel, helper.FormatMessage(message, a), helper.ErrorFields(err, logging.FatalLevel, r.callerSkip))
+	}
+	r.exit()
+}
+
+// Panic пишет запись как пользовательский уровень (см. Log): zerolog.PanicLevel строже Fatal.
+func (r *ZerologLogger) Panic(message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	r.log(logging.PanicLevel, msg, nil)
+	panic(msg)
+}
+
+func (r *ZerologLogger) PanicCatch(err error, message string, a ...any) {
+	msg := helper.FormatMessage(message, a)
+	if r.Enabled(logging.PanicLevel) {
+		r.log(logging.PanicLevel, msg, helper.ErrorFields(err, logging.PanicLevel, r.callerSkip))
+	}
+	if err != nil {
+		panic(err)
+	}
+	panic(msg)
+}
+
+func (r *ZerologLogger) Tracew(message string, keysAndValues ...any) {
+	r.log(logging.TraceLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZerologLogger) Debugw(message string, keysAndValues ...any) {
+	r.log(logging.DebugLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZerologLogger) Infow(message string, keysAndValues ...any) {
+	r.log(logging.InfoLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZerologLogger) Warnw(message string, keysAndValues ...any) {
+	r.log(logging.WarnLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZerologLogger) Errorw(message string, keysAndValues ...any) {
+	r.log(logging.ErrorLevel, message, helper.KeysAndValues(keysAndValues))
+}
+
+func (r *ZerologLogger) Fatalw(message string, keysAndValues ...any) {
+	r.log(logging.FatalLevel, message, helper.KeysAndValues(keysAndValues))
+	r.exit()
+}
+
+// Log пишет запись на уровне level. Пользовательский уровень проверяется как ближайший
+// менее строгий уровень zerolog, а в поле zerolog.LevelFieldName выводится его имя.
+func (r *ZerologLogger) Log(level logging.Level, message string, a ...any) {
+	if _, ok := helper.BackendLevel(levelMap, level); !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(level, helper.FormatMessage(message, a), nil)
+	if level == logging.FatalLevel {
+		r.exit()
+	}
+}
+
+func (r *ZerologLogger) Logw(level logging.Level, message string, keysAndValues ...any) {
+	if _, ok := helper.BackendLevel(levelMap, level); !ok {
+		r.Warn("Invalid logging level: %v", level)
+		return
+	}
+	r.log(level, message, helper.KeysAndValues(keysAndValues))
+	if level == logging.FatalLevel {
+		r.exit()
+	}
+}
+
+// customLevel возвращает уровень zerolog и поля записи для level. Уровень без собственного
+// уровня zerolog пишется как zerolog.NoLevel с именем в поле уровня, поэтому zerolog его
+// не фильтрует и порог проверяется в log.
+func customLevel(level logging.Level, fields map[string]interface{}) (zerolog.Level, map[string]interface{}) {
+	if helper.NativeLevel(levelMap, level, true) {
+		return levelMap[level], fields
+	}
+	return zerolog.NoLevel, helper.MergeContext(fields, map[string]interface{}{zerolog.LevelFieldName: level.String()})
+}
+
+// BASE
+
+func (r *ZerologLogger) Clone() logging.Logger {
+	return r.derive(r.GetAllContexts())
+}
+
+func (r *ZerologLogger) With(keysAndValues ...any) logging.Logger {
+	return r.derive(helper.MergeContext(r.GetAllContexts(), helper.KeysAndValues(keysAndValues)))
+}
+
+func (r *ZerologLogger) Named(name string) logging.Logger {
+	fullName := logging.JoinName(r.name, name)
+	child := r.derive(helper.MergeContext(r.GetAllContexts(), map[string]interface{}{logging.LoggerNameKey: fullName}))
+	child.name = fullName
+	child.level = r.level.ChildWithLookup(helper.NamedLevelLookup(fullName, levels))
+	return child
+}
+
+// derive создает дочерний логгер с общим zerolog.Logger, наследующий уровень r.
+func (r *ZerologLogger) derive(context map[string]interface{}) *ZerologLogger {
+	return &ZerologLogger{
+		zerolog:     r.zerolog,
+		context:     context,
+		name:        r.name,
+		atomicLevel: r.atomicLevel,
+		rootLevel:   r.rootLevel,
+		level:       r.level.Child(),
+		exitFunc:    r.exitFunc,
+		callerSkip:  r.callerSkip,
+		caller:      r.caller,
+	}
+}
+
+func (r *ZerologLogger) WithCallerSkip(skip int) logging.Logger {
+	child := r.derive(r.GetAllContexts())
+	child.callerSkip += skip
+	return child
+}
+
+func (r *ZerologLogger) WithCaller(pc uintptr) logging.Logger {
+	child := r.derive(r.GetAllContexts())
+	child.caller = pc
+	return child
+}
+
+// SetCtx возвращает производный логгер, привязанный к ctx: с traceID и spanID активного
+// спана и уровнем запроса, если он есть в ctx (см. logging.LevelFromContext). r не изменяется.
+func (r *ZerologLogger) SetCtx(ctx context.Context) logging.Logger {
+	child := r.derive(helper.MergeContext(r.GetAllContexts(), helper.SpanFields(ctx)))
+	if level, ok := helper.ContextLevel(ctx, levels); ok {
+		child.level.SetOverride(level)
+	}
+	return child
+}
+
+func (r *ZerologLogger) SetExitFunc(exitFunc logging.ExitFunc) {
+	r.exitFunc = exitFunc
+}
+
+// Sync ничего не делает: zerolog.Logger не дает доступа к своему writer. Буферизующий
+// writer, например diode.Writer, закрывается тем, кто его создал.
+func (r *ZerologLogger) Sync() error {
+	return nil
+}
+
+func (r *ZerologLogger) exit() {
+	_ = r.Sync()
+	r.exitFunc(1)
+}
+
+func (r *ZerologLogger) log(level logging.Level, message string, fields map[string]interface{}) {
+	if !r.Enabled(level) {
+		return
+	}
+	zerologLevel, fields := customLevel(level, fields)
+	// WithLevel не завершает процесс на FatalLevel, выход выполняется через exit
+	event := r.zerolog.WithLevel(zerologLevel)
+	if event == nil {
+		return
+	}
+	// zerolog.Context.Caller указал бы на обертку, поэтому место вызова пишется здесь
+	frame := helper.CallerFrame(r.callerPC())
+	event.Str(zerolog.CallerFieldName, zerolog.CallerMarshalFunc(frame.PC, frame.File, frame.Line)).
+		Str(FuncFieldName, frame.Function).
+		Fields(helper.MergeContext(r.GetAllContexts(), fields)).
+		Msg(message)
+}
+
+// callerPC возвращает место вызова публичного метода логгера. Вызывается из log.
+func (r *ZerologLogger) callerPC() uintptr {
+	if r.caller != 0 {
+		return r.caller
+	}
+	return helper.CallerPC(3 + r.callerSkip)
+}
+
+var _ logging.Logger = &ZerologLogger{}
+var _ logging.CallerSetter = &ZerologLogger{}
@@ -0,0 +1,88 @@
+package zerologlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"testing"
+)
+
+func getZerologLogger() (*ZerologLogger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	zl := zerolog.New(buf)
+	return NewZerologLogger(&zl, NewAtomicLevel(zerolog.DebugLevel)), buf
+}
+
+func TestBaseLogger_SetLevel(t *testing.T) {
+	logger, _ := getZerologLogger()
+	logger.SetLevel(logging.WarnLevel)
+	assert.Equal(t, zerolog.WarnLevel, logger.atomicLevel.Level(), "Log level should be set to Warn")
+}
+
+func TestZerologLogger_Output(t *testing.T) {
+	logger, buf := getZerologLogger()
+
+	logger.Trace("hidden")
+	assert.Empty(t, buf.String(), "Trace should be filtered at Debug level")
+
+	logger.AddContexts(map[string]interface{}{"count": 3, "enabled": true})
+	logger.Info("visible")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "visible", entry["message"])
+	assert.Equal(t, float64(3), entry["count"])
+	assert.Equal(t, true, entry["enabled"])
+}
+
+func TestZerologLogger_CustomLevel(t *testing.T) {
+	notice := logging.MustRegisterLevel("notice", 14, logging.Green)
+	logger, buf := getZerologLogger()
+
+	logger.Logw(notice, "custom", "key", "value")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "notice", entry["level"])
+	assert.Equal(t, "custom", entry["message"])
+	assert.Equal(t, "value", entry["key"])
+
+	buf.Reset()
+	logger.SetLevel(logging.WarnLevel)
+	logger.Log(notice, "hidden")
+	assert.Empty(t, buf.String())
+}
+
+func TestZerologLogger_WrappedLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zl := zerolog.New(buf).Level(zerolog.InfoLevel)
+	logger := NewZerologLogger(&zl, nil)
+	assert.Equal(t, logging.InfoLevel, logger.GetLevel(), "Wrapped logger level should become the initial level")
+
+	logger.Debug("hidden")
+	assert.Empty(t, buf.String())
+
+	logger.SetLevel(logging.DebugLevel)
+	assert.True(t, logger.Enabled(logging.DebugLevel))
+	logger.Debug("debug")
+	assert.Contains(t, buf.String(), "debug", "Wrapped logger level should not filter after SetLevel")
+
+	buf.Reset()
+	child := logger.Clone()
+	child.SetLevel(logging.TraceLevel)
+	child.Trace("trace")
+	assert.Contains(t, buf.String(), "trace", "Clone override below the wrapped level should be written")
+}
+
+func TestZerologLogger_GlobalLevel(t *testing.T) {
+	logger, _ := getZerologLogger()
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	defer zerolog.SetGlobalLevel(zerolog.TraceLevel)
+
+	assert.False(t, logger.Enabled(logging.InfoLevel), "Enabled should honour zerolog.GlobalLevel")
+	assert.True(t, logger.Enabled(logging.WarnLevel))
+}
@@ -3,6 +3,10 @@ package logging
 import "context"
 
 type Logger interface {
+	// AddContext и AddContexts сохраняют значения с их исходным типом, чтобы бэкенд
+	// мог вывести числа, bool, объекты и массивы как есть. Значения, которые нельзя
+	// закодировать (функции, каналы, unsafe.Pointer, комплексные числа), сохраняются
+	// в виде строки "%v".
 	AddContext(key string, value interface{}) Logger
 	AddContexts(contexts map[string]interface{}) Logger
 	DeleteContext(key string) Logger
@@ -14,10 +18,59 @@ type Logger interface {
 	Warn(message string, a ...any)
 	Error(message string, a ...any)
 	Fatal(message string, a ...any)
+	// ErrorCatch и FatalCatch не меняют сообщение, а выводят err в поле ErrorKey:
+	// текст, конкретный тип и цепочку причин. Так записи с одинаковым сообщением
+	// группируются независимо от текста ошибки.
 	ErrorCatch(err error, message string, a ...any)
 	FatalCatch(err error, message string, a ...any)
+	// Panic и PanicCatch пишут запись на PanicLevel и затем вызывают panic: Panic —
+	// с текстом сообщения, PanicCatch — с err, если он не nil.
+	Panic(message string, a ...any)
+	PanicCatch(err error, message string, a ...any)
 
+	// Методы с суффиксом w принимают чередующиеся ключи и значения и добавляют их
+	// как поля только к этой записи, не изменяя контекст логгера.
+	Tracew(message string, keysAndValues ...any)
+	Debugw(message string, keysAndValues ...any)
+	Infow(message string, keysAndValues ...any)
+	Warnw(message string, keysAndValues ...any)
+	Errorw(message string, keysAndValues ...any)
+	Fatalw(message string, keysAndValues ...any)
+
+	// Log и Logw пишут запись на уровне level, в том числе пользовательском
+	// (см. RegisterLevel). На FatalLevel они ведут себя как Fatal и Fatalw, а на
+	// PanicLevel только пишут запись; незарегистрированный уровень не пишется.
+	Log(level Level, message string, a ...any)
+	Logw(level Level, message string, keysAndValues ...any)
+
+	// SetLevel у корневого логгера меняет уровень бэкенда. Логгеры, полученные через
+	// Clone и With, наследуют действующий уровень родителя; SetLevel задает им
+	// собственный уровень, не влияя на родителя и соседей, а ResetLevel снимает его.
 	SetLevel(level Level)
+	ResetLevel()
+	// GetLevel возвращает действующий уровень логгера, Enabled — будет ли выведена
+	// запись уровня level. Позволяет не собирать дорогие данные для отключенных уровней.
+	GetLevel() Level
+	Enabled(level Level) bool
 	Clone() Logger
+	// With возвращает производный логгер с тем же бэкендом и уровнем и собственным
+	// набором полей: контекст текущего логгера, дополненный keysAndValues.
+	// Текущий логгер не изменяется.
+	With(keysAndValues ...any) Logger
+	// Named возвращает производный логгер, имя которого добавлено к имени текущего
+	// через точку ("db" -> "db.pool"). Полное имя выводится в поле LoggerNameKey,
+	// уровень берется из NamedLevels по самому длинному подходящему префиксу, а при
+	// его отсутствии наследуется от текущего логгера.
+	Named(name string) Logger
+	// WithCallerSkip возвращает производный логгер, записи которого указывают место
+	// вызова на skip кадров выше. Нужен вспомогательным функциям логирования, чтобы в
+	// поле caller попадал их вызывающий, а не они сами.
+	WithCallerSkip(skip int) Logger
+	// SetCtx возвращает производный логгер, привязанный к ctx: с traceID и spanID
+	// активного спана и, если в ctx есть уровень запроса (см. LevelFromContext), с этим
+	// уровнем, как после SetLevel у клона. Текущий логгер не изменяется.
 	SetCtx(ctx context.Context) Logger
+	// Sync сбрасывает буферизованный вывод бэкенда. Ошибки синхронизации терминалов и
+	// каналов (os.Stdout, os.Stderr) не возвращаются: их вывод не буферизуется.
+	Sync() error
 }
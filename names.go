@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// LoggerNameKey — поле, в котором именованные логгеры выводят свое полное имя.
+const LoggerNameKey = "logger"
+
+// JoinName соединяет имя родителя и дочернее имя через точку: "db" + "pool" = "db.pool".
+func JoinName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
+	}
+	return parent + "." + name
+}
+
+// LevelRegistry хранит уровни для префиксов имен логгеров.
+//
+// Префикс "db" относится к логгерам "db", "db.pool" и "db.pool.conn", но не к "dbx".
+// При нескольких подходящих префиксах используется самый длинный.
+type LevelRegistry struct {
+	mu     sync.RWMutex
+	levels map[string]Level
+}
+
+func NewLevelRegistry() *LevelRegistry {
+	return &LevelRegistry{levels: make(map[string]Level)}
+}
+
+// NamedLevels — реестр, который используют логгеры, созданные через Named.
+var NamedLevels = NewLevelRegistry()
+
+func (r *LevelRegistry) Set(prefix string, level Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.levels[prefix] = level
+}
+
+func (r *LevelRegistry) Delete(prefix string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.levels, prefix)
+}
+
+// SetSpec задает уровни из строки вида "db=debug,http.client=warn".
+// При ошибке разбора реестр не изменяется.
+func (r *LevelRegistry) SetSpec(spec string) error {
+	levels := make(map[string]Level)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		prefix, levelName, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("invalid named level %q: expected name=level", item)
+		}
+		level, err := ParseLevel(levelName)
+		if err != nil {
+			return err
+		}
+		levels[strings.TrimSpace(prefix)] = level
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for prefix, level := range levels {
+		r.levels[prefix] = level
+	}
+	return nil
+}
+
+// Reset удаляет все уровни.
+func (r *LevelRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.levels = make(map[string]Level)
+}
+
+// Levels возвращает копию всех заданных уровней.
+func (r *LevelRegistry) Levels() map[string]Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	levels := make(map[string]Level, len(r.levels))
+	for prefix, level := range r.levels {
+		levels[prefix] = level
+	}
+	return levels
+}
+
+// Lookup возвращает уровень самого длинного префикса, подходящего к name.
+func (r *LevelRegistry) Lookup(name string) (Level, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		found  bool
+		level  Level
+		length = -1
+	)
+	for prefix, prefixLevel := range r.levels {
+		if len(prefix) <= length {
+			continue
+		}
+		if name == prefix || strings.HasPrefix(name, prefix+".") {
+			found, level, length = true, prefixLevel, len(prefix)
+		}
+	}
+	return level, found
+}
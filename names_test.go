@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestJoinName(t *testing.T) {
+	assert.Equal(t, "db", JoinName("", "db"))
+	assert.Equal(t, "db.pool", JoinName("db", "pool"))
+	assert.Equal(t, "db", JoinName("db", ""))
+}
+
+func TestLevelRegistry_Lookup(t *testing.T) {
+	registry := NewLevelRegistry()
+	registry.Set("db", DebugLevel)
+	registry.Set("db.pool", TraceLevel)
+	registry.Set("http", WarnLevel)
+
+	tests := []struct {
+		name      string
+		wantLevel Level
+		wantFound bool
+	}{
+		{name: "db", wantLevel: DebugLevel, wantFound: true},
+		{name: "db.query", wantLevel: DebugLevel, wantFound: true},
+		{name: "db.pool.conn", wantLevel: TraceLevel, wantFound: true},
+		{name: "http.client", wantLevel: WarnLevel, wantFound: true},
+		{name: "dbx", wantFound: false},
+		{name: "cache", wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, found := registry.Lookup(tt.name)
+			assert.Equal(t, tt.wantFound, found)
+			if tt.wantFound {
+				assert.Equal(t, tt.wantLevel, level)
+			}
+		})
+	}
+
+	registry.Delete("db.pool")
+	level, _ := registry.Lookup("db.pool.conn")
+	assert.Equal(t, DebugLevel, level, "Deleted prefix should fall back to shorter one")
+}
+
+func TestLevelRegistry_SetSpec(t *testing.T) {
+	registry := NewLevelRegistry()
+
+	require.NoError(t, registry.SetSpec("db=debug, http.client=warn"))
+	assert.Equal(t, map[string]Level{"db": DebugLevel, "http.client": WarnLevel}, registry.Levels())
+
+	assert.Error(t, registry.SetSpec("cache=loud"))
+	assert.Error(t, registry.SetSpec("cache"))
+	assert.Len(t, registry.Levels(), 2, "Invalid spec should not change the registry")
+}
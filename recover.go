@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+const (
+	// PanicKey — поле со значением перехваченной паники.
+	PanicKey = "panic"
+	// StacktraceKey — поле со стеком вызовов.
+	StacktraceKey = "stacktrace"
+)
+
+type recoverConfig struct {
+	repanic bool
+	message string
+}
+
+// RecoverOption настраивает Recover.
+type RecoverOption func(*recoverConfig)
+
+// Repanic продолжает панику с исходным значением после записи. По умолчанию Recover
+// подавляет панику.
+func Repanic() RecoverOption {
+	return func(c *recoverConfig) {
+		c.repanic = true
+	}
+}
+
+// RecoverMessage задает сообщение записи вместо "Recovered from panic".
+func RecoverMessage(message string) RecoverOption {
+	return func(c *recoverConfig) {
+		c.message = message
+	}
+}
+
+// Recover перехватывает панику и пишет ее в logger на PanicLevel с полями PanicKey и
+// StacktraceKey (полный стек горутины). Местом вызова записи указывается функция,
+// вызвавшая panic, если logger реализует CallerSetter. Вызывается только через defer:
+//
+//	defer logging.Recover(logctx.L(ctx))
+func Recover(logger Logger, opts ...RecoverOption) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	config := recoverConfig{message: "Recovered from panic"}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if setter, ok := logger.(CallerSetter); ok {
+		if pc := panicPC(); pc != 0 {
+			logger = setter.WithCaller(pc)
+		}
+	}
+	logger.Logw(PanicLevel, config.message, PanicKey, fmt.Sprint(value), StacktraceKey, string(debug.Stack()))
+	if config.repanic {
+		panic(value)
+	}
+}
+
+// panicPC возвращает pc функции, вызвавшей panic, или 0, если ее нет в стеке.
+// Вызывается из Recover: над ним в стеке находится runtime.gopanic.
+func panicPC() uintptr {
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	panicking := false
+	for _, pc := range pcs[:n] {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		if panicking && !strings.HasPrefix(frame.Function, "runtime.") {
+			return pc
+		}
+		panicking = panicking || frame.Function == "runtime.gopanic"
+	}
+	return 0
+}
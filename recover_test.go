@@ -0,0 +1,79 @@
+package logging_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/zaplog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func newObservedLogger() (logging.Logger, *observer.ObservedLogs) {
+	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core, logs := observer.New(atomicLevel)
+	return zaplog.NewZapLogger(zap.New(core), atomicLevel), logs
+}
+
+func TestRecover(t *testing.T) {
+	logger, logs := newObservedLogger()
+	var panicLine int
+
+	assert.NotPanics(t, func() {
+		defer logging.Recover(logger.With("worker", 7))
+		_, _, panicLine, _ = runtime.Caller(0)
+		panic("boom")
+	})
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.PanicLevel, entries[0].Level)
+	assert.Equal(t, "Recovered from panic", entries[0].Message)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "boom", fields[logging.PanicKey])
+	assert.Equal(t, int64(7), fields["worker"], "Logger context should be kept")
+	assert.Contains(t, fields[logging.StacktraceKey], "TestRecover", "Stack should include the panicking goroutine")
+	assert.Equal(t, "recover_test.go", filepath.Base(entries[0].Caller.File), "Caller should be the panicking frame")
+	assert.Equal(t, panicLine+1, entries[0].Caller.Line)
+}
+
+func TestRecover_RuntimePanic(t *testing.T) {
+	logger, logs := newObservedLogger()
+	var values []int
+
+	assert.NotPanics(t, func() {
+		defer logging.Recover(logger)
+		_ = values[3]
+	})
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].Caller.Function, "TestRecover_RuntimePanic", "Runtime frames should be skipped")
+}
+
+func TestRecover_Repanic(t *testing.T) {
+	logger, logs := newObservedLogger()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		defer logging.Recover(logger, logging.Repanic(), logging.RecoverMessage("worker crashed"))
+		panic("boom")
+	})
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "worker crashed", entries[0].Message)
+}
+
+func TestRecover_NoPanic(t *testing.T) {
+	logger, logs := newObservedLogger()
+
+	func() {
+		defer logging.Recover(logger)
+	}()
+
+	assert.Zero(t, logs.Len())
+}
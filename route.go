@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"io"
+	"os"
+)
+
+// Route направляет в Writer записи уровня Level и выше, пока не начнется маршрут
+// с более высоким Level.
+type Route struct {
+	Level  Level
+	Writer io.Writer
+}
+
+// Routes — маршруты вывода по уровню записи. Запись попадает в маршрут с наибольшим
+// Level, не превышающим ее уровень; порядок маршрутов не важен. Запись уровнем ниже
+// всех маршрутов не выводится.
+type Routes []Route
+
+// DefaultRoutes направляет Trace, Debug и Info в os.Stdout, а Warn и выше — в os.Stderr.
+func DefaultRoutes() Routes {
+	return Routes{
+		{Level: TraceLevel, Writer: os.Stdout},
+		{Level: WarnLevel, Writer: os.Stderr},
+	}
+}
+
+// Writer возвращает приемник для записи уровня level или nil, если маршрута нет.
+func (r Routes) Writer(level Level) io.Writer {
+	if i := r.Index(level); i >= 0 {
+		return r[i].Writer
+	}
+	return nil
+}
+
+// Index возвращает индекс маршрута для записи уровня level или -1, если маршрута нет.
+func (r Routes) Index(level Level) int {
+	index := -1
+	for i := range r {
+		if r[i].Level <= level && (index < 0 || r[i].Level > r[index].Level) {
+			index = i
+		}
+	}
+	return index
+}
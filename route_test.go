@@ -0,0 +1,32 @@
+package logging
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestRoutes_Writer(t *testing.T) {
+	info, warn, fatal := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+	routes := Routes{
+		{Level: FatalLevel, Writer: fatal},
+		{Level: InfoLevel, Writer: info},
+		{Level: WarnLevel, Writer: warn},
+	}
+
+	assert.Nil(t, routes.Writer(DebugLevel), "Level below every route should be dropped")
+	assert.Same(t, info, routes.Writer(InfoLevel))
+	assert.Same(t, info, routes.Writer(Level(14)))
+	assert.Same(t, warn, routes.Writer(WarnLevel))
+	assert.Same(t, warn, routes.Writer(PanicLevel))
+	assert.Same(t, fatal, routes.Writer(FatalLevel))
+}
+
+func TestDefaultRoutes(t *testing.T) {
+	routes := DefaultRoutes()
+	assert.Equal(t, os.Stdout, routes.Writer(TraceLevel))
+	assert.Equal(t, os.Stdout, routes.Writer(InfoLevel))
+	assert.Equal(t, os.Stderr, routes.Writer(WarnLevel))
+	assert.Equal(t, os.Stderr, routes.Writer(FatalLevel))
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	shutdownMu     sync.Mutex
+	shutdownFuncs  = make(map[uint64]func(ctx context.Context) error)
+	shutdownNextID uint64
+)
+
+// OnShutdown регистрирует f для вызова из Shutdown и возвращает функцию, отменяющую
+// регистрацию. Фабрики пакета factory регистрируют себя при создании первого логгера.
+func OnShutdown(f func(ctx context.Context) error) (cancel func()) {
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	id := shutdownNextID
+	shutdownNextID++
+	shutdownFuncs[id] = f
+	return func() {
+		shutdownMu.Lock()
+		defer shutdownMu.Unlock()
+		delete(shutdownFuncs, id)
+	}
+}
+
+// Shutdown сбрасывает вывод всех бэкендов, зарегистрированных через OnShutdown.
+// Функции вызываются параллельно; если ctx завершится раньше, Shutdown возвращает
+// ctx.Err(), не дожидаясь оставшихся. Иначе возвращаются их ошибки, объединенные
+// через errors.Join.
+func Shutdown(ctx context.Context) error {
+	shutdownMu.Lock()
+	funcs := make([]func(ctx context.Context) error, 0, len(shutdownFuncs))
+	for _, f := range shutdownFuncs {
+		funcs = append(funcs, f)
+	}
+	shutdownMu.Unlock()
+
+	errs := make([]error, len(funcs))
+	var wg sync.WaitGroup
+	for i, f := range funcs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = f(ctx)
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return errors.Join(errs...)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
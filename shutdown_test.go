@@ -0,0 +1,49 @@
+package logging_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vsysa/logging"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	var calls []string
+	done := make(chan string, 2)
+	cancelFirst := logging.OnShutdown(func(ctx context.Context) error {
+		done <- "first"
+		return nil
+	})
+	cancelSecond := logging.OnShutdown(func(ctx context.Context) error {
+		done <- "second"
+		return errors.New("sync failed")
+	})
+	defer cancelSecond()
+
+	assert.EqualError(t, logging.Shutdown(context.Background()), "sync failed")
+	close(done)
+	for name := range done {
+		calls = append(calls, name)
+	}
+	assert.ElementsMatch(t, []string{"first", "second"}, calls)
+
+	cancelFirst()
+	cancelSecond()
+	assert.NoError(t, logging.Shutdown(context.Background()), "Cancelled functions should not be called")
+}
+
+func TestShutdown_Deadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	cancel := logging.OnShutdown(func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	defer cancel()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelCtx()
+	assert.ErrorIs(t, logging.Shutdown(ctx), context.DeadlineExceeded, "Shutdown should not wait past the deadline")
+}
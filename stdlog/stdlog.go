@@ -0,0 +1,122 @@
+// Package stdlog перенаправляет вывод стандартного пакета log в logging.Logger.
+package stdlog
+
+import (
+	"bytes"
+	"github.com/vsysa/logging"
+	"log"
+	"strings"
+)
+
+// Prefix сопоставляет префикс строки с уровнем логирования. Сравнение без учета регистра.
+type Prefix struct {
+	Prefix string
+	Level  logging.Level
+}
+
+// DefaultPrefixes — префиксы, которые распознает PrefixDetector(DefaultPrefixes...).
+var DefaultPrefixes = []Prefix{
+	{"TRACE:", logging.TraceLevel},
+	{"[trace]", logging.TraceLevel},
+	{"DEBUG:", logging.DebugLevel},
+	{"[debug]", logging.DebugLevel},
+	{"INFO:", logging.InfoLevel},
+	{"[info]", logging.InfoLevel},
+	{"WARN:", logging.WarnLevel},
+	{"WARNING:", logging.WarnLevel},
+	{"[warn]", logging.WarnLevel},
+	{"[warning]", logging.WarnLevel},
+	{"ERROR:", logging.ErrorLevel},
+	{"[error]", logging.ErrorLevel},
+}
+
+// LevelDetector определяет уровень строки. Возвращает сообщение без распознанного
+// префикса; ok == false означает, что будет использован уровень по умолчанию.
+type LevelDetector func(line string) (level logging.Level, message string, ok bool)
+
+// PrefixDetector создает LevelDetector, распознающий заданные префиксы.
+func PrefixDetector(prefixes ...Prefix) LevelDetector {
+	return func(line string) (logging.Level, string, bool) {
+		for _, p := range prefixes {
+			if len(line) >= len(p.Prefix) && strings.EqualFold(line[:len(p.Prefix)], p.Prefix) {
+				return p.Level, strings.TrimLeft(line[len(p.Prefix):], " \t"), true
+			}
+		}
+		return 0, line, false
+	}
+}
+
+type Options struct {
+	// Level — уровень для строк, уровень которых не определен. По умолчанию InfoLevel.
+	Level logging.Level
+	// DetectLevel определяет уровень по содержимому строки. По умолчанию PrefixDetector(DefaultPrefixes...).
+	DetectLevel LevelDetector
+}
+
+// Writer — io.Writer, который пишет вывод каждого вызова пакета log одной записью
+// в logging.Logger.
+//
+// FatalLevel пишется как Error: log.Fatal сам завершает процесс после записи.
+// Местом вызова записи указывается вызов функции пакета log.
+type Writer struct {
+	logger      logging.Logger
+	level       logging.Level
+	detectLevel LevelDetector
+}
+
+// callerSkip — кадры между вызовом logger в emit и вызовом функции пакета log:
+// Writer.Write, log.(*Logger).output и log.Printf или log.(*Logger).Output.
+const callerSkip = 4
+
+func NewWriter(logger logging.Logger, opts Options) *Writer {
+	w := &Writer{
+		logger:      logger.WithCallerSkip(callerSkip),
+		level:       opts.Level,
+		detectLevel: opts.DetectLevel,
+	}
+	if w.level == 0 {
+		w.level = logging.InfoLevel
+	}
+	if w.detectLevel == nil {
+		w.detectLevel = PrefixDetector(DefaultPrefixes...)
+	}
+	return w
+}
+
+// Write пишет p одной записью: пакет log вызывает Write один раз на каждый вызов, поэтому
+// многострочное сообщение, например со стеком, остается одной записью с уровнем, найденным
+// по ее началу.
+func (w *Writer) Write(p []byte) (int, error) {
+	line := bytes.TrimRight(p, "\r\n")
+	if len(line) == 0 {
+		return len(p), nil
+	}
+	level, message, ok := w.detectLevel(string(line))
+	if !ok {
+		level = w.level
+	}
+	w.emit(level, message)
+	return len(p), nil
+}
+
+func (w *Writer) emit(level logging.Level, message string) {
+	if level == logging.FatalLevel {
+		level = logging.ErrorLevel
+	}
+	w.logger.Log(level, message)
+}
+
+// Redirect устанавливает Writer в стандартный логгер через log.SetOutput и log.SetFlags(0).
+// Возвращает функцию, восстанавливающую прежние вывод и флаги.
+func Redirect(logger logging.Logger, opts Options) (restore func()) {
+	prevOutput := log.Writer()
+	prevFlags := log.Flags()
+
+	log.SetOutput(NewWriter(logger, opts))
+	log.SetFlags(0)
+
+	return func() {
+		log.SetOutput(prevOutput)
+		log.SetFlags(prevFlags)
+	}
+}
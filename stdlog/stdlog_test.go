@@ -0,0 +1,96 @@
+package stdlog
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsysa/logging"
+	"github.com/vsysa/logging/logger/zaplog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"log"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func getObservedLogger() (logging.Logger, *observer.ObservedLogs) {
+	atomicLevel := zap.NewAtomicLevelAt(zap.DebugLevel)
+	core, logs := observer.New(atomicLevel)
+	return zaplog.NewZapLogger(zap.New(core), atomicLevel), logs
+}
+
+func TestRedirect(t *testing.T) {
+	logger, logs := getObservedLogger()
+
+	restore := Redirect(logger, Options{Level: logging.WarnLevel})
+	log.Printf("plain %d%%", 100)
+	log.Print("ERROR: disk full")
+	log.Print("[debug] cache miss\nsecond line")
+	restore()
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, "plain 100%", entries[0].Message)
+	assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+	assert.Equal(t, "disk full", entries[1].Message)
+	assert.Equal(t, zapcore.DebugLevel, entries[2].Level, "Continuation lines should keep the detected level")
+	assert.Equal(t, "cache miss\nsecond line", entries[2].Message, "One log call should produce one record")
+
+	assert.NotEqual(t, 0, log.Flags(), "Restore should bring back previous flags")
+}
+
+func TestRedirect_Caller(t *testing.T) {
+	logger, logs := getObservedLogger()
+
+	restore := Redirect(logger, Options{})
+	_, _, line, _ := runtime.Caller(0)
+	log.Print("printed")
+	_ = log.Output(1, "output")
+	log.New(NewWriter(logger, Options{}), "", 0).Printf("own logger")
+	restore()
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	for i, entry := range entries {
+		assert.Equal(t, "stdlog_test.go", filepath.Base(entry.Caller.File), entry.Message)
+		assert.Equal(t, line+1+i, entry.Caller.Line, entry.Message)
+		assert.Contains(t, entry.Caller.Function, "TestRedirect_Caller", entry.Message)
+	}
+}
+
+func TestWriter_Levels(t *testing.T) {
+	logger, logs := getObservedLogger()
+	notice := logging.MustRegisterLevel("stdlog-notice", 14, logging.Cyan)
+	w := NewWriter(logger, Options{DetectLevel: PrefixDetector(
+		Prefix{"NOTICE:", notice},
+		Prefix{"PANIC:", logging.PanicLevel},
+		Prefix{"FATAL:", logging.FatalLevel},
+	)})
+
+	for _, line := range []string{"NOTICE: quota\n", "PANIC: recovered\n", "FATAL: exiting\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	noticeLevel, _ := zaplog.CustomLevel(notice)
+	assert.Equal(t, noticeLevel, entries[0].Level, "Custom level should be kept")
+	assert.Equal(t, zapcore.PanicLevel, entries[1].Level, "Panic should be logged without panicking")
+	assert.Equal(t, zapcore.ErrorLevel, entries[2].Level, "Fatal should be logged as Error")
+}
+
+func TestPrefixDetector_Custom(t *testing.T) {
+	detect := PrefixDetector(Prefix{"E ", logging.ErrorLevel})
+
+	level, message, ok := detect("e something broke")
+	assert.True(t, ok)
+	assert.Equal(t, logging.ErrorLevel, level)
+	assert.Equal(t, "something broke", message)
+
+	_, message, ok = detect("ERROR: not configured")
+	assert.False(t, ok)
+	assert.Equal(t, "ERROR: not configured", message)
+}